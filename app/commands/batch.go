@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	errBatchInvalid = errors.New("some entries are invalid, nothing reported")
+	errBatchFailed  = errors.New("some entries were not reported")
+)
+
+// batchItem is an entry to report among many, labeled by where it came from, e.g. a line in the file.
+// Once prepared, the entry is aligned into one or more entries to report.
+type batchItem struct {
+	label   string
+	entry   types.ReportEntry
+	aligned types.ReportEntries
+	err     error
+}
+
+type batch []batchItem
+
+// reportRecords reports the records as a batch, labeling each with the label func. Nothing is reported
+// if any of the records is invalid, or if confirm, when given, fails for the prepared batch. Items of the returned
+// batch follow the records and have the error set for the ones that were not reported; the batch is nil
+// if it failed before that.
+func reportRecords(
+	ctx context.Context, p printer.Printer, cfg config.Config, api *services.API,
+	records importer.Records, label func(importer.Record) string, confirm func(batch) error,
+) (batch, error) {
+	day, err := workday(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("workday: %w", err)
+	}
+
+	items := make(batch, 0, len(records))
+
+	for _, record := range records {
+		item := batchItem{label: label(record)}
+		item.entry, item.err = record.Entry(cfg.DefaultProject)
+		items = append(items, item)
+	}
+
+	if items, err = items.prepare(ctx, api, day, types.ProjectAliases(cfg.ProjectAliases)); err != nil {
+		return nil, fmt.Errorf("prepare: %w", err)
+	}
+
+	if err = items.check(p); err != nil {
+		return items, fmt.Errorf("check: %w", err)
+	}
+
+	if confirm != nil {
+		if err = confirm(items); err != nil {
+			return items, fmt.Errorf("confirm: %w", err)
+		}
+	}
+
+	if err = items.report(ctx, p, api); err != nil {
+		return items, fmt.Errorf("report: %w", err)
+	}
+
+	return items, nil
+}
+
+// prepare resolves project names and aligns times of the entries as if they were reported one by one,
+// so that conflicts are found before anything is reported. History is fetched once per month touched.
+func (b batch) prepare(
+	ctx context.Context, api *services.API, day types.Workday, aliases types.ProjectAliases,
+) (batch, error) {
+	var (
+		projects  types.Projects
+		histories = map[string]*types.ReportEntries{}
+	)
+
+	group, cctx := errgroup.WithContext(ctx)
+	group.Go(getProjects(cctx, api, &projects))
+
+	for _, item := range b {
+		month := item.entry.ReportDate.Format("2006-01")
+		if _, ok := histories[month]; ok || item.err != nil {
+			continue
+		}
+
+		histories[month] = &types.ReportEntries{}
+		group.Go(getHistory(cctx, api, item.entry.ReportDate, histories[month]))
+	}
+
+	if err := group.Wait(); err != nil {
+		return b, fmt.Errorf("group: %w", err)
+	}
+
+	out := make(batch, 0, len(b))
+
+	for _, item := range b {
+		if item.err == nil {
+			history := histories[item.entry.ReportDate.Format("2006-01")]
+			item.aligned, item.err = prepareEntry(item.entry, projects, aliases, history, day)
+		}
+
+		out = append(out, item)
+	}
+
+	return out, nil
+}
+
+func prepareEntry(
+	entry types.ReportEntry, projects types.Projects, aliases types.ProjectAliases,
+	history *types.ReportEntries, day types.Workday,
+) (types.ReportEntries, error) {
+	entry, err := entry.UpdateProjectName(projects, aliases)
+	if err != nil {
+		return nil, fmt.Errorf("fixup project name: %w", err)
+	}
+
+	entries, err := entry.AlignTimes(*history, day)
+	if err != nil {
+		return nil, fmt.Errorf("align: %w", err)
+	}
+
+	*history = append(*history, entries...)
+
+	return entries, nil
+}
+
+// skip drops the items failed with the error, printing each to stderr as skipped.
+func (b batch) skip(p printer.Printer, target error) batch {
+	out := make(batch, 0, len(b))
+
+	for _, item := range b {
+		if errors.Is(item.err, target) {
+			p.ErrPrintln(item.label + ": skipped, " + target.Error())
+
+			continue
+		}
+
+		out = append(out, item)
+	}
+
+	return out
+}
+
+// check prints invalid items, if any, to stderr along with the rest of the messages that are not the result.
+func (b batch) check(p printer.Printer) error {
+	var invalid bool
+
+	for _, item := range b {
+		if item.err != nil {
+			invalid = true
+
+			p.ErrPrintln(item.label + ": " + item.err.Error())
+		}
+	}
+
+	if invalid {
+		return errBatchInvalid
+	}
+
+	return nil
+}
+
+// plan prints where the items go once reported.
+func (b batch) plan(p printer.Printer) {
+	for _, item := range b {
+		for _, entry := range item.aligned {
+			fromTo := entry.StartTime.Format("15:04") + "-" + entry.EndTime.Format("15:04")
+			p.ErrPrintln(item.label + ": " + fromTo + " " + entry.Project.Name + ": " + entry.Description)
+		}
+	}
+}
+
+// confirm prints the plan and asks whether to report it, unless --yes is given.
+func (b batch) confirm(c *cli.Context, p printer.Printer, question string) error {
+	b.plan(p)
+
+	if c.Bool(flagYes) {
+		return nil
+	}
+
+	ok, err := confirm(p, stdin(c), question)
+	if err != nil {
+		return fmt.Errorf("confirm: %w", err)
+	}
+
+	if !ok {
+		return errCancelled
+	}
+
+	return nil
+}
+
+// report reports the items one by one and prints the result of each. Items that failed get the error set.
+func (b batch) report(ctx context.Context, p printer.Printer, api *services.API) error {
+	var failed bool
+
+	for i, item := range b {
+		for _, entry := range item.aligned {
+			reported, err := api.Report(ctx, entry)
+			if err != nil {
+				failed = true
+				b[i].err = err
+
+				p.ErrPrintln(item.label + ": " + err.Error())
+
+				continue
+			}
+
+			p.Println(item.label + ": " + reported.String())
+		}
+	}
+
+	if failed {
+		return errBatchFailed
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+var yearRegexp = regexp.MustCompile(`^\d{4}$`)
+
+func Calendar(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "calendar",
+		Usage: "show the year or the month colored by hours reported",
+		Description: "" +
+			"Show the calendar of the year, or of the month, with the weeks as columns, like GitHub contributions.\n" +
+			"Days are shaded by the hours reported; weekends, holidays, vacations by the type and working days\n" +
+			"with nothing reported are marked.\n\n" +
+			"    vkpm calendar\n" +
+			"    vkpm calendar --for 2024\n" +
+			"    vkpm calendar --for 2024-05",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "this year",
+				Usage: "year in format YYYY, or " + usageMonth,
+			},
+		},
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "calendar")
+			defer end()
+
+			from, to, err := calendarRange(c)
+			if err != nil {
+				return fmt.Errorf("range: %w", err)
+			}
+
+			today := types.Today()
+			if from.After(today.Time) {
+				return errFutureDay
+			}
+
+			// months after this one have nothing reported yet
+			last := to
+			if last.After(today.Time) {
+				last = today
+			}
+
+			var (
+				history   types.ReportEntries
+				vacations types.Vacations
+				holidays  types.Holidays
+			)
+
+			group, gctx := errgroup.WithContext(ctx)
+
+			group.Go(func() error {
+				var err error
+				if history, err = historyRange(gctx, api, from, last); err != nil {
+					return fmt.Errorf("history: %w", err)
+				}
+
+				return nil
+			})
+
+			group.Go(func() error {
+				var err error
+				if vacations, holidays, err = vacationsHolidaysRange(gctx, api, from, to); err != nil {
+					return fmt.Errorf("vacations holidays: %w", err)
+				}
+
+				return nil
+			})
+
+			if err = group.Wait(); err != nil {
+				return fmt.Errorf("group: %w", err)
+			}
+
+			if err = p.Value(types.NewCalendar(from, to, history, vacations, holidays)); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// calendarRange is the first and the last day of the year or the month in --for, this year by default.
+func calendarRange(c *cli.Context) (types.Date, types.Date, error) {
+	value := c.String(flagFor)
+	if len(value) == 0 {
+		value = strconv.Itoa(types.Today().Year())
+	}
+
+	if yearRegexp.MatchString(value) {
+		year, _ := strconv.Atoi(value)
+		from := types.Date{Time: time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)}
+
+		return from, from.AddDate(1, 0, -1), nil
+	}
+
+	month, err := monthFlag(c, flagFor)
+	if err != nil {
+		return month, month, err
+	}
+
+	return month, month.AddDate(0, 1, -1), nil
+}
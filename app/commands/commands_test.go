@@ -0,0 +1,685 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/commands"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/kudrykv/go-vkpm/app/vkpmtest"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/urfave/cli/v2"
+)
+
+func TestCommands(t *testing.T) {
+	// the day is fixed, so that the dates the commands take relative to it do not depend on the run
+	now := types.Today
+	defer func() { types.Today = now }()
+
+	types.Today = func() types.Date {
+		return types.Date{Time: time.Date(2021, time.June, 17, 12, 0, 0, 0, time.Local)}
+	}
+
+	Convey("commands against the stand-in server", t, func() {
+		egg := types.Project{ID: "7", Name: "Egg Inc."}
+		today := types.Today()
+
+		server := vkpmtest.NewServer().
+			WithProjects(egg, types.Project{ID: "9", Name: "Kube For Startups"}).
+			WithPersons(types.Person{ID: 12, Name: "Jane Doe", Team: "Mobile", Birthday: today.Time}).
+			WithVacations(5)
+		defer server.Close()
+
+		cfg := server.Config(server.Session("john"))
+		api := services.NewAPI(server.LittleHTTP(), cfg).WithCookies(cfg.Cookies)
+		out := &bytes.Buffer{}
+		errOut := &bytes.Buffer{}
+		output := printer.OutputText
+		p := printer.Printer{W: out, E: errOut, Output: &output}
+
+		app := &cli.App{
+			Writer: out,
+			Commands: []*cli.Command{
+				commands.Dashboard(p, cfg, api),
+				commands.Today(p, cfg, api),
+				commands.Report(p, cfg, api),
+				commands.History(p, cfg, api),
+				commands.Week(p, cfg, api),
+				commands.Calendar(p, cfg, api),
+				commands.Export(p, cfg, api),
+				commands.Stat(p, cfg, api),
+				commands.Vacations(p, cfg, api),
+				commands.UsersSearch(p, cfg, api),
+				commands.UsersInfo(p, cfg, api),
+				commands.ProjectsList(p, cfg, api),
+			},
+		}
+
+		run := func(args ...string) error {
+			out.Reset()
+			errOut.Reset()
+
+			return app.RunContext(context.Background(), append([]string{"vkpm"}, args...))
+		}
+
+		Convey("report and history", func() {
+			So(run("report", "-p", "egg", "-s", "1h30m", "-m", "doing stuff"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(09:00-10:30) for Egg Inc.")
+
+			So(run("report", "-p", "egg", "-s", "1h", "-m", "more stuff"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(10:30-11:30) for Egg Inc.")
+
+			entries := server.Entries()
+			So(entries, ShouldHaveLength, 2)
+			So(entries[1].Span, ShouldEqual, time.Hour)
+
+			So(run("history"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "more stuff")
+		})
+
+		Convey("report and history for relative dates", func() {
+			So(run("report", "-F", "yesterday", "-p", "egg", "-s", "1h", "-m", "late"), ShouldBeNil)
+			So(server.Entries()[0].ReportDate.Equal(today.AddDate(0, 0, -1)), ShouldBeTrue)
+
+			So(run("history", "-F", "yesterday"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "late")
+
+			So(run("report", "-F", "someday", "-p", "egg", "-s", "1h", "-m", "late"), ShouldBeError)
+		})
+
+		Convey("report skips the lunch", func() {
+			lunch := cfg
+			lunch.Workday = config.Workday{Start: "10:00", Lunch: "12:00-13:00"}
+			app.Commands = []*cli.Command{commands.Report(p, lunch, api)}
+
+			So(run("report", "-p", "egg", "-s", "3h", "-m", "doing stuff"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(10:00-12:00) for Egg Inc.")
+			So(out.String(), ShouldContainSubstring, "(13:00-14:00) for Egg Inc.")
+			So(server.Entries(), ShouldHaveLength, 2)
+		})
+
+		Convey("report fills the gaps", func() {
+			meeting, _ := time.Parse("15:04", "11:00")
+			server.WithEntries(types.ReportEntry{
+				ReportDate: today, Project: egg, Activity: types.ActivityManagement, Description: "meeting",
+				Status: 100, StartTime: meeting, EndTime: meeting.Add(time.Hour), Span: time.Hour,
+			})
+
+			So(run("report", "-p", "egg", "-s", "3h", "-m", "coding", "--fill"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(09:00-11:00) for Egg Inc.")
+			So(out.String(), ShouldContainSubstring, "(12:00-13:00) for Egg Inc.")
+			So(server.Entries(), ShouldHaveLength, 3)
+		})
+
+		Convey("report dry run", func() {
+			So(run("report", "-p", "egg", "-s", "1h", "-m", "maybe", "--dry-run"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(09:00-10:00) for Egg Inc.")
+			So(out.String(), ShouldContainSubstring, "project_id:          7")
+			So(server.Entries(), ShouldBeEmpty)
+			So(server.Count("POST /report/"), ShouldEqual, 0)
+		})
+
+		Convey("report import", func() {
+			file := filepath.Join(t.TempDir(), "week.csv")
+			date := today.Format("2006-01-02")
+
+			csv := "date,project,span,from,to,message\n" +
+				date + ",egg,1h,,,first\n" +
+				date + ",kube,,12:00,13:00,second\n" +
+				date + ",egg,2h,,,third\n"
+			So(os.WriteFile(file, []byte(csv), 0600), ShouldBeNil)
+			So(run("report", "import", file), ShouldBeNil)
+
+			entries := server.Entries()
+			So(entries, ShouldHaveLength, 3)
+			So(entries[2].Description, ShouldEqual, "third")
+			So(entries[2].StartTime.Format("15:04"), ShouldEqual, "13:00")
+
+			Convey("nothing is reported when some lines are invalid", func() {
+				csv = "date,project,from,to,message\n" +
+					date + ",egg,15:00,16:00,fine\n" +
+					date + ",egg,12:30,13:30,overlaps\n"
+				So(os.WriteFile(file, []byte(csv), 0600), ShouldBeNil)
+				So(run("report", "import", file), ShouldBeError)
+				So(errOut.String(), ShouldContainSubstring, "line 3: align")
+				So(server.Entries(), ShouldHaveLength, 3)
+			})
+		})
+
+		Convey("report rejects ambiguous projects", func() {
+			server.WithProjects(types.Project{ID: "11", Name: "Egg Farm"})
+
+			err := run("report", "-p", "eg", "-s", "1h", "-m", "stuff")
+			So(errors.Is(err, types.ErrTooPermissive), ShouldBeTrue)
+			So(err.Error(), ShouldContainSubstring, "Egg Inc., Egg Farm")
+			So(server.Entries(), ShouldBeEmpty)
+
+			So(run("report", "-p", "egg inc", "-s", "1h", "-m", "stuff"), ShouldBeNil)
+			So(server.Entries()[0].Project, ShouldResemble, egg)
+		})
+
+		Convey("report from a template", func() {
+			templated := cfg
+			templated.Templates = map[string]config.Template{
+				"standup": {Project: "kube", Activity: "management", Span: 20 * time.Minute, Message: "standup"},
+			}
+			app.Commands = []*cli.Command{commands.Report(p, templated, api)}
+
+			So(run("report", "--tpl", "standup", "-m", "standup, planning"), ShouldBeNil)
+
+			entry := server.Entries()[0]
+			So(entry.Project.Name, ShouldEqual, "Kube For Startups")
+			So(entry.Activity, ShouldEqual, types.ActivityManagement)
+			So(entry.Span, ShouldEqual, 20*time.Minute)
+			So(entry.Description, ShouldEqual, "standup, planning")
+
+			So(run("report", "--template", "retro"), ShouldBeError)
+		})
+
+		Convey("report fill", func() {
+			may := func(day int) types.Date {
+				return types.Date{Time: time.Date(2021, time.May, day, 0, 0, 0, 0, time.UTC)}
+			}
+
+			nine, _ := time.Parse("15:04", "09:00")
+			server.
+				WithEntries(types.ReportEntry{
+					ReportDate: may(3), Project: egg, Activity: types.ActivityDevelopment, Description: "done",
+					Status: 100, StartTime: nine, EndTime: nine.Add(8 * time.Hour), Span: 8 * time.Hour,
+				}).
+				WithHolidays(types.Holiday{Name: "Labour Day", Date: may(4)}).
+				WithVacations(5, types.Vacation{
+					ID: "3", Type: "Vacation", Status: "Approved", Paid: true,
+					StartDate: may(10), EndDate: may(11), Span: 48 * time.Hour,
+				})
+
+			filling := cfg
+			filling.Templates = map[string]config.Template{
+				"regular": {Project: "egg", Span: 8 * time.Hour, Message: "regular work"},
+			}
+			app.Commands = []*cli.Command{commands.Report(p, filling, api)}
+
+			app.Reader = strings.NewReader("n\n")
+			So(run("report", "fill", "-F", "2021-05", "--tpl", "regular"), ShouldBeError)
+			So(errOut.String(), ShouldContainSubstring, "Wednesday, 05: 09:00-17:00 Egg Inc.: regular work")
+			So(errOut.String(), ShouldNotContainSubstring, "Tuesday, 04")
+			So(errOut.String(), ShouldNotContainSubstring, "Monday, 10")
+			So(errOut.String(), ShouldContainSubstring, "[y/N]")
+			So(out.String(), ShouldBeEmpty)
+			So(server.Entries(), ShouldHaveLength, 1)
+
+			So(run("report", "fill", "-F", "2021-05", "--tpl", "regular", "--yes"), ShouldBeNil)
+			So(server.Entries(), ShouldHaveLength, 18)
+
+			So(run("report", "fill", "-F", "2021-05", "--tpl", "regular"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Nothing to fill in May 2021")
+		})
+
+		Convey("report resolves project aliases", func() {
+			aliased := cfg
+			aliased.ProjectAliases = map[string]string{"k4s": "Kube For Startups"}
+			app.Commands = []*cli.Command{commands.Report(p, aliased, api)}
+
+			So(run("report", "-p", "K4S", "-s", "1h", "-m", "stuff"), ShouldBeNil)
+			So(server.Entries()[0].Project.Name, ShouldEqual, "Kube For Startups")
+		})
+
+		Convey("timer", func() {
+			timed, err := config.New(context.Background(), t.TempDir(), "")
+			So(err, ShouldBeNil)
+
+			timed.Domain, timed.Cookies = cfg.Domain, cfg.Cookies
+			app.Commands = []*cli.Command{commands.Timer(p, timed, api)}
+
+			So(run("timer", "start", "-p", "egg", "-m", "fixing", "--at", "09:02"), ShouldBeNil)
+			So(run("timer", "start", "-p", "egg", "-m", "again"), ShouldBeError)
+
+			So(run("timer", "status"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "since 09:02: egg, fixing")
+
+			So(run("timer", "stop", "--at", "10:34", "--later"), ShouldBeNil)
+			So(server.Entries(), ShouldBeEmpty)
+
+			So(run("timer", "status"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Timer is not running")
+			So(out.String(), ShouldContainSubstring, "09:00-10:30: egg, fixing")
+
+			So(run("timer", "flush"), ShouldBeNil)
+			So(server.Entries(), ShouldHaveLength, 1)
+			So(server.Entries()[0].Span, ShouldEqual, 90*time.Minute)
+			So(run("timer", "flush"), ShouldBeError)
+
+			So(run("timer", "start", "-p", "egg", "--at", "11:00"), ShouldBeNil)
+			So(run("timer", "stop", "-m", "reviewing", "--at", "12:00"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "(11:00-12:00) for Egg Inc.")
+			So(server.Entries(), ShouldHaveLength, 2)
+
+			So(run("timer", "stop"), ShouldBeError)
+		})
+
+		Convey("notes", func() {
+			noted, err := config.New(context.Background(), t.TempDir(), "")
+			So(err, ShouldBeNil)
+
+			noted.Domain, noted.Cookies = cfg.Domain, cfg.Cookies
+			app.Commands = []*cli.Command{commands.Note(p, noted), commands.Report(p, noted, api)}
+
+			So(run("report", "-p", "egg", "-s", "1h", "--from-notes"), ShouldBeError)
+
+			So(run("note", "fixed", "flaky", "test"), ShouldBeNil)
+			So(run("note", "-p", "kube", "deployed the release"), ShouldBeNil)
+			So(run("note", "@egg reviewed PRs"), ShouldBeNil)
+
+			So(run("note"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "@kube deployed the release")
+
+			Convey("as the message", func() {
+				So(run("report", "-p", "egg", "-s", "1h", "--from-notes"), ShouldBeNil)
+
+				entries := server.Entries()
+				So(entries, ShouldHaveLength, 1)
+				So(entries[0].Description, ShouldEqual, "fixed flaky test\ndeployed the release\nreviewed PRs")
+			})
+
+			Convey("split by project", func() {
+				So(run("report", "-p", "egg", "-s", "3h", "--from-notes", "--split"), ShouldBeNil)
+
+				entries := server.Entries()
+				So(entries, ShouldHaveLength, 2)
+				So(entries[0].Description, ShouldEqual, "fixed flaky test\nreviewed PRs")
+				So(entries[0].Span, ShouldEqual, 90*time.Minute)
+				So(entries[1].Project.Name, ShouldEqual, "Kube For Startups")
+				So(entries[1].StartTime.Format("15:04"), ShouldEqual, "10:30")
+
+				err := run("report", "-p", "egg", "--from", "10:00", "--to", "12:00", "--from-notes", "--split")
+				So(err, ShouldBeError)
+				So(err.Error(), ShouldContainSubstring, "from-to range")
+			})
+
+			So(run("report", "-p", "egg", "-s", "1h", "-m", "both", "--from-notes"), ShouldBeError)
+			So(run("report", "-p", "egg", "-s", "1h", "-m", "no notes", "--split"), ShouldBeError)
+		})
+
+		Convey("suggest from git", func() {
+			repo := t.TempDir()
+			at := time.Date(today.Year(), today.Month(), today.Day(), 10, 0, 0, 0, time.Local)
+
+			git(t, repo, at, "init", "-q")
+			git(t, repo, at, "-c", "user.email=john@example.com", "commit", "-q", "--allow-empty", "-m", "fix login")
+			git(t, repo, at, "-c", "user.email=john@example.com", "commit", "-q", "--allow-empty", "-m", "add tests")
+
+			suggested := cfg
+			suggested.Repos = map[string]string{filepath.Base(repo): "kube"}
+			app.Commands = []*cli.Command{commands.Report(p, suggested, api)}
+
+			So(run("report", "suggest", "-r", repo, "--git-author", "jane@example.com"), ShouldBeError)
+
+			// slice flags keep the values between runs, so the commands are rebuilt
+			app.Commands = []*cli.Command{commands.Report(p, suggested, api)}
+			So(run("report", "suggest", "-r", repo, "--git-author", "john@example.com", "-s", "2h"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "project: kube")
+			So(out.String(), ShouldContainSubstring, "span: 2h\n")
+
+			app.Reader = strings.NewReader(out.String())
+			So(run("report", "import", "-"), ShouldBeNil)
+
+			entries := server.Entries()
+			So(entries, ShouldHaveLength, 1)
+			So(entries[0].Project.Name, ShouldEqual, "Kube For Startups")
+			So(entries[0].Description, ShouldEqual, "fix login\nadd tests")
+		})
+
+		Convey("report from calendar", func() {
+			path := filepath.Join(t.TempDir(), "calendar.ics")
+			So(os.WriteFile(path, []byte(""+
+				"BEGIN:VCALENDAR\n"+
+				"BEGIN:VEVENT\nSUMMARY:Standup\nDTSTART:20210510T090500\nDTEND:20210510T092000\n"+
+				"RRULE:FREQ=DAILY\nEND:VEVENT\n"+
+				"BEGIN:VEVENT\nSUMMARY:Focus time\nDTSTART:20210513T100000\nDTEND:20210513T120000\nEND:VEVENT\n"+
+				"BEGIN:VEVENT\nSUMMARY:Kube planning\nDTSTART:20210513T140000\nDTEND:20210513T150000\nEND:VEVENT\n"+
+				"BEGIN:VEVENT\nSUMMARY:Sync\nDTSTART:20210513T143000\nDTEND:20210513T153000\nEND:VEVENT\n"+
+				"END:VCALENDAR\n",
+			), 0600), ShouldBeNil)
+
+			meetings := cfg
+			meetings.DefaultProject = "egg"
+			meetings.Meetings = []config.Meeting{
+				{Match: "focus", Skip: true},
+				{Match: "kube", Project: "kube", Activity: types.ActivityAnalysis},
+			}
+			app.Commands = []*cli.Command{commands.Report(p, meetings, api)}
+
+			app.Reader = strings.NewReader("n\n")
+			So(run("report", "from-ics", "--for", "2021-05-13", path), ShouldBeError)
+			So(errOut.String(), ShouldContainSubstring, "09:05-09:20: 09:10-09:20 Egg Inc.: Standup")
+			So(server.Entries(), ShouldBeEmpty)
+
+			So(run("report", "from-ics", "--for", "2021-05-13", "--yes", path), ShouldBeNil)
+
+			entries := server.Entries()
+			So(entries, ShouldHaveLength, 2)
+			So(entries[0].Activity, ShouldEqual, types.ActivityManagement)
+			So(entries[1].Project.Name, ShouldEqual, "Kube For Startups")
+			So(entries[1].Activity, ShouldEqual, types.ActivityAnalysis)
+			So(entries[1].StartTime.Format("15:04"), ShouldEqual, "14:00")
+			So(errOut.String(), ShouldContainSubstring, "14:30-15:30: skipped, overlaps with existing")
+
+			So(run("report", "from-ics", "--for", "2021-05-13", "--yes", path), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "No meetings left to report")
+			So(server.Entries(), ShouldHaveLength, 2)
+
+			So(run("report", "from-ics", "--for", "2021-05-09", path), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "No meetings")
+		})
+
+		Convey("import from tracker", func() {
+			path := filepath.Join(t.TempDir(), "toggl.csv")
+			So(os.WriteFile(path, []byte(""+
+				"Project,Description,Start date,Start time,End date,End time,Tags\n"+
+				"Egg (client),fixing login,2021-05-13,09:02:11,2021-05-13,10:28:40,\n"+
+				"Egg (client),quick call,2021-05-13,10:28:40,2021-05-13,10:31:02,\n"+
+				"K4S,planning,2021-05-13,10:31:02,2021-05-13,11:30:00,meeting\n",
+			), 0600), ShouldBeNil)
+
+			tracked := cfg
+			tracked.ProjectAliases = map[string]string{"egg (client)": "Egg Inc.", "k4s": "kube"}
+			app.Commands = []*cli.Command{commands.Import(p, tracked, api)}
+
+			So(run("import", "--format", "harvest", path), ShouldBeError)
+
+			app.Reader = strings.NewReader("n\n")
+			So(run("import", "--format", "toggl", path), ShouldBeError)
+			So(errOut.String(), ShouldContainSubstring, "Report 2 entries? [y/N]")
+			So(server.Entries(), ShouldBeEmpty)
+
+			So(run("import", "--format", "toggl", "--yes", path), ShouldBeNil)
+			So(errOut.String(), ShouldContainSubstring, "entry 3: skipped, shorter than 10 minutes")
+			So(errOut.String(), ShouldContainSubstring, "entry 2: 09:00-10:30 Egg Inc.: fixing login")
+
+			entries := server.Entries()
+			So(entries, ShouldHaveLength, 2)
+			So(entries[1].Project.Name, ShouldEqual, "Kube For Startups")
+			So(entries[1].StartTime.Format("15:04"), ShouldEqual, "10:30")
+		})
+
+		Convey("dashboard and stat", func() {
+			So(run("dashboard"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Hours in month")
+
+			So(run("stat"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "report")
+		})
+
+		Convey("vacations", func() {
+			So(run("vacations"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "5 day(s) of paid vacations left")
+		})
+
+		Convey("users and projects", func() {
+			So(run("search", "--team", "mob"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Jane Doe")
+
+			So(run("info", "jane"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Team: Mobile")
+
+			So(run("list"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Egg Inc., Kube For Startups")
+		})
+
+		Convey("history over months with filters", func() {
+			at, _ := time.Parse("15:04", "09:00")
+			entry := func(
+				date types.Date, project types.Project, activity, description string, span time.Duration,
+			) types.ReportEntry {
+				return types.ReportEntry{
+					ReportDate: date, Project: project, Activity: activity, Name: project.Name, Description: description,
+					Status: 100, StartTime: at, EndTime: at.Add(span), Span: span,
+				}
+			}
+			k4s := types.Project{ID: "9", Name: "Kube For Startups"}
+			lastMonth := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.Local)
+			server.WithEntries(
+				entry(types.Date{Time: lastMonth.AddDate(0, -1, 0)}, egg, types.ActivityDevelopment, "too old", time.Hour),
+				entry(types.Date{Time: lastMonth}, egg, types.ActivityDevelopment, "login form", 2*time.Hour),
+				entry(types.Date{Time: lastMonth.AddDate(0, 0, 1)}, k4s, types.ActivityManagement, "planning", time.Hour),
+				entry(today, egg, types.ActivityManagement, "login sync", 30*time.Minute),
+			)
+
+			format := "{{.Description}}"
+			So(run("history", "--from", lastMonth.Format("2006-01"), "--format", format), ShouldBeNil)
+			So(out.String(), ShouldEqual, "login form\nplanning\nlogin sync\n")
+
+			So(run("history", "--from", lastMonth.Format("2006-01"), "-p", "egg", "--format", format), ShouldBeNil)
+			So(out.String(), ShouldEqual, "login form\nlogin sync\n")
+
+			So(run("history", "--from", "-12w", "--grep", "LOGIN", "--min-span", "1h", "--format", format), ShouldBeNil)
+			So(out.String(), ShouldEqual, "login form\n")
+
+			So(run("history", "--to", lastMonth.Format("2006-01"), "-a", "man", "--format", format), ShouldBeNil)
+			So(out.String(), ShouldEqual, "planning\n")
+
+			So(run("history", "--for", "today", "--from", "-1w"), ShouldBeError)
+			So(run("history", "--from", "today", "--to", "yesterday"), ShouldBeError)
+		})
+
+		Convey("today", func() {
+			meeting, _ := time.Parse("15:04", "11:00")
+			server.WithEntries(types.ReportEntry{
+				ReportDate: today, Project: egg, Activity: types.ActivityManagement, Description: "meeting",
+				Status: 100, StartTime: meeting, EndTime: meeting.Add(time.Hour), Span: time.Hour,
+			})
+			So(run("report", "-p", "egg", "-s", "1h30m", "-m", "doing stuff"), ShouldBeNil)
+
+			So(run("today"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "09:00-11:00 free, 2:00")
+			So(out.String(), ShouldContainSubstring, "meeting")
+			So(out.String(), ShouldContainSubstring, "12:00-13:30")
+			So(out.String(), ShouldContainSubstring, "13:30-17:00 free, 3:30")
+			So(out.String(), ShouldContainSubstring, "Reported 2:30 of 8:00, 5:30 left")
+			So(out.String(), ShouldContainSubstring, "starts at 13:30")
+		})
+
+		Convey("week", func() {
+			So(run("report", "-p", "egg", "-s", "1h30m", "-m", "doing stuff"), ShouldBeNil)
+
+			So(run("week"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Egg Inc.")
+			So(out.String(), ShouldContainSubstring, today.Format("Mon 02"))
+
+			_, week := today.AddDate(0, 0, -7).ISOWeek()
+			So(run("week", "--for", "-1w"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, fmt.Sprintf("W%d", week))
+			So(out.String(), ShouldNotContainSubstring, "Egg Inc.")
+
+			So(run("week", "--for", "2024-W54"), ShouldBeError)
+
+			// the week of Apr 26 - May 02, 2021 takes the history of both months
+			at, _ := time.Parse("15:04", "09:00")
+			server.WithEntries(
+				types.ReportEntry{
+					ReportDate: types.Date{Time: time.Date(2021, time.April, 30, 0, 0, 0, 0, time.Local)},
+					Project:    egg, Activity: types.ActivityDevelopment, Description: "april",
+					Status: 100, StartTime: at, EndTime: at.Add(2 * time.Hour), Span: 2 * time.Hour,
+				},
+				types.ReportEntry{
+					ReportDate: types.Date{Time: time.Date(2021, time.May, 1, 0, 0, 0, 0, time.Local)},
+					Project:    egg, Activity: types.ActivityDevelopment, Description: "may",
+					Status: 100, StartTime: at, EndTime: at.Add(time.Hour), Span: time.Hour,
+				},
+			)
+
+			requests := server.Count("POST /history/")
+			So(run("week", "--for", "2021-W17"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "Apr 26 - May 02")
+			So(out.String(), ShouldContainSubstring, "Fri 30")
+			So(out.String(), ShouldContainSubstring, "Sat 01")
+			So(out.String(), ShouldContainSubstring, "3:00 / ")
+			So(server.Count("POST /history/")-requests, ShouldEqual, 2)
+		})
+
+		Convey("calendar", func() {
+			server.WithHolidays(types.Holiday{Name: "Day Off", Date: today.AddDate(0, 0, 1)})
+			So(run("report", "-p", "egg", "-s", "8h", "-m", "doing stuff"), ShouldBeNil)
+
+			So(run("calendar"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, today.Format("2006"))
+			So(out.String(), ShouldContainSubstring, "Reported 8:00 in 1 day(s)")
+
+			So(run("calendar", "--for", today.Format("2006-01")), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, today.Format("January 2006"))
+			So(out.String(), ShouldContainSubstring, fmt.Sprintf("%2d█", today.Day()))
+
+			output = printer.OutputJSON
+			So(run("calendar", "--for", today.Format("2006-01")), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, `"kind": "holiday"`)
+
+			So(run("calendar", "--for", strconv.Itoa(today.Year()+1)), ShouldBeError)
+		})
+
+		Convey("export report", func() {
+			So(run("report", "-p", "egg", "-s", "1h30m", "-m", "doing stuff"), ShouldBeNil)
+
+			So(run("export", "report"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "# Activity report, "+today.Format("January 2006"))
+			So(out.String(), ShouldContainSubstring, "| Egg Inc. | 1.50 | 100% |")
+
+			So(run("export", "report", "--for", today.Format("2006-01"), "--format", "html", "-p", "egg"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "doing stuff")
+
+			So(run("export", "report", "--format", "pdf"), ShouldBeError)
+		})
+
+		Convey("format with a template", func() {
+			So(run("report", "-p", "egg", "-s", "1h30m", "-m", "doing stuff"), ShouldBeNil)
+
+			So(run("history", "--format", "{{.Project.Name}}: {{.Description}}, {{hours .Span}}h"), ShouldBeNil)
+			So(out.String(), ShouldEqual, "Egg Inc.: doing stuff, 1.5h\n")
+
+			So(run("search", "--format", "{{.Name}} ({{.Team}})"), ShouldBeNil)
+			So(out.String(), ShouldEqual, "Jane Doe (Mobile)\n")
+
+			So(run("stat", "--format", "{{.Month}} {{len .History}}"), ShouldBeNil)
+			So(out.String(), ShouldEndWith, today.Month().String()+" 1\n")
+
+			named := cfg
+			named.Formats = map[string]string{"standup": "- {{.Description}}"}
+			app.Commands = []*cli.Command{commands.History(p, named, api)}
+
+			So(run("history", "--format", "standup"), ShouldBeNil)
+			So(out.String(), ShouldEqual, "- doing stuff\n")
+
+			So(run("history", "--format", "{{.Nope}}"), ShouldBeError)
+		})
+
+		Convey("structured output", func() {
+			So(run("report", "-p", "egg", "-s", "1h", "-m", "doing stuff"), ShouldBeNil)
+
+			output = printer.OutputJSON
+			So(run("history"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, `"project": "Egg Inc."`)
+			So(out.String(), ShouldContainSubstring, `"hours": 1`)
+
+			So(run("vacations"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, `"paid_days_left": 5`)
+
+			output = printer.OutputYAML
+			So(run("info", "jane"), ShouldBeNil)
+			So(out.String(), ShouldContainSubstring, "team: Mobile")
+
+			output = printer.OutputCSV
+			So(run("list"), ShouldBeNil)
+			So(out.String(), ShouldEqual, "id,name\n7,Egg Inc.\n9,Kube For Startups\n")
+
+			So(run("dashboard"), ShouldBeNil)
+			So(out.String(), ShouldStartWith, "month,hours_in_month,")
+		})
+	})
+}
+
+func TestLogin(t *testing.T) {
+	Convey("login", t, func() {
+		ctx := context.Background()
+		server := vkpmtest.NewServer().WithUser("john", "secret")
+		defer server.Close()
+
+		cfg, err := config.New(ctx, t.TempDir(), "")
+		So(err, ShouldBeNil)
+
+		cfg.Domain = server.Domain()
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		p := printer.Printer{W: out, E: errOut}
+
+		run := func(cfg config.Config, stdin string, args ...string) (config.Config, error) {
+			app := &cli.App{
+				Reader:   strings.NewReader(stdin),
+				Writer:   out,
+				Commands: []*cli.Command{commands.Login(p, cfg, services.NewAPI(server.LittleHTTP(), cfg))},
+			}
+
+			if err := app.RunContext(ctx, append([]string{"vkpm", "login"}, args...)); err != nil {
+				return cfg, err
+			}
+
+			return cfg.Read()
+		}
+
+		Convey("password from stdin", func() {
+			read, err := run(cfg, "secret\n", "--username", "john", "--password-stdin")
+			So(err, ShouldBeNil)
+			So(read.Username, ShouldEqual, "john")
+			So(read.Cookies.IsZero(), ShouldBeFalse)
+
+			_, err = run(cfg, "wrong\n", "--username", "john", "--password-stdin")
+			So(errors.Is(err, services.ErrBadCreds), ShouldBeTrue)
+
+			_, err = run(cfg, "secret\n", "--password-stdin")
+			So(err, ShouldBeError)
+		})
+
+		Convey("password command", func() {
+			cfg.PasswordCommand = "echo secret"
+
+			read, err := run(cfg, "john\n")
+			So(err, ShouldBeNil)
+			So(read.Cookies.IsZero(), ShouldBeFalse)
+			So(errOut.String(), ShouldEqual, "username: ")
+			So(out.String(), ShouldBeEmpty)
+
+			username, password, err := commands.Credentials(p, read)(ctx)
+			So(err, ShouldBeNil)
+			So(username, ShouldEqual, "john")
+			So(password, ShouldEqual, "secret")
+
+			So(commands.CanRelogin(read, false), ShouldBeTrue)
+
+			read.PasswordCommand = ""
+			So(commands.CanRelogin(read, false), ShouldBeFalse)
+			So(commands.CanRelogin(read, true), ShouldBeTrue)
+		})
+	})
+}
+
+func git(t *testing.T, repo string, at time.Time, args ...string) {
+	t.Helper()
+
+	date := at.Format(time.RFC3339)
+
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date, "GIT_CONFIG_GLOBAL=/dev/null")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/kudrykv/go-vkpm/app/config"
 	"github.com/kudrykv/go-vkpm/app/th"
@@ -10,12 +13,22 @@ import (
 )
 
 const (
-	flagDomain  = "domain"
-	flagDefProj = "defproj"
+	flagDomain   = "domain"
+	flagDefProj  = "defproj"
+	flagPassCmd  = "password-command"
+	flagDayStart = "day-start"
+	flagLunch    = "lunch"
+	flagStacking = "stacking"
+	flagAlias    = "alias"
+	flagRepo     = "repo"
+	flagAuthor   = "git-author"
 )
 
 var (
 	httpsRegexp = regexp.MustCompile(`^https?://`)
+
+	errBadAlias = errors.New("alias must be in format alias=project name")
+	errBadRepo  = errors.New("repo must be in format path=project name")
 )
 
 func Config(cfg config.Config) *cli.Command {
@@ -25,6 +38,26 @@ func Config(cfg config.Config) *cli.Command {
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: flagDomain, Usage: "domain to use, e.g., domain.com"},
 			&cli.StringFlag{Name: flagDefProj, Usage: "report time for the given project if none specifed in report"},
+			&cli.StringFlag{
+				Name:  flagPassCmd,
+				Usage: "command printing the password to sign in with, e.g., 'pass show vkpm'; empty to unset",
+			},
+			&cli.StringFlag{Name: flagDayStart, Usage: "time the reports with a span start from, e.g., 09:00"},
+			&cli.StringFlag{Name: flagLunch, Usage: "lunch the reports with a span skip, e.g., 13:00-14:00; empty to unset"},
+			&cli.StringFlag{
+				Name: flagStacking,
+				Usage: "where reports with a span go: latest to stack after the latest entry, " +
+					"first-gap to the first fitting gap, fill to split over the earliest gaps",
+			},
+			&cli.StringSliceFlag{
+				Name:  flagAlias,
+				Usage: "project alias, e.g., k4s='Kube For Startups (internal)'; empty project name removes it",
+			},
+			&cli.StringSliceFlag{
+				Name:  flagRepo,
+				Usage: "project of a git repo by its path or directory name, e.g., ~/src/egg=egg; empty project name removes it",
+			},
+			&cli.StringFlag{Name: flagAuthor, Usage: "git author to suggest reports from, git user.email if not set"},
 		},
 
 		Action: func(c *cli.Context) error {
@@ -43,6 +76,58 @@ func Config(cfg config.Config) *cli.Command {
 				cfg.DefaultProject = defProj
 			}
 
+			if c.IsSet(flagPassCmd) {
+				cfg.PasswordCommand = c.String(flagPassCmd)
+			}
+
+			if c.IsSet(flagDayStart) {
+				cfg.Workday.Start = c.String(flagDayStart)
+			}
+
+			if c.IsSet(flagLunch) {
+				cfg.Workday.Lunch = c.String(flagLunch)
+			}
+
+			if c.IsSet(flagStacking) {
+				cfg.Workday.Stacking = c.String(flagStacking)
+			}
+
+			for _, alias := range c.StringSlice(flagAlias) {
+				name, project, ok := strings.Cut(alias, "=")
+				if name = strings.TrimSpace(name); !ok || len(name) == 0 {
+					return fmt.Errorf("%s: %w", alias, errBadAlias)
+				}
+
+				cfg.ProjectAliases = setProject(cfg.ProjectAliases, name, project)
+			}
+
+			for _, repo := range c.StringSlice(flagRepo) {
+				path, project, ok := strings.Cut(repo, "=")
+				if path = strings.TrimSpace(path); !ok || len(path) == 0 {
+					return fmt.Errorf("%s: %w", repo, errBadRepo)
+				}
+
+				// paths are kept absolute, and bare names match the directory name of any repo
+				if path == "." || strings.ContainsRune(path, filepath.Separator) {
+					abs, err := filepath.Abs(path)
+					if err != nil {
+						return fmt.Errorf("abs: %w", err)
+					}
+
+					path = abs
+				}
+
+				cfg.Repos = setProject(cfg.Repos, path, project)
+			}
+
+			if c.IsSet(flagAuthor) {
+				cfg.GitAuthor = c.String(flagAuthor)
+			}
+
+			if _, err := workday(cfg); err != nil {
+				return fmt.Errorf("workday: %w", err)
+			}
+
 			if err := cfg.Write(); err != nil {
 				return fmt.Errorf("write config: %w", err)
 			}
@@ -51,3 +136,20 @@ func Config(cfg config.Config) *cli.Command {
 		},
 	}
 }
+
+// setProject maps the key to the project, or removes the key if the project is empty.
+func setProject(projects map[string]string, key, project string) map[string]string {
+	if project = strings.TrimSpace(project); len(project) == 0 {
+		delete(projects, key)
+
+		return projects
+	}
+
+	if projects == nil {
+		projects = map[string]string{}
+	}
+
+	projects[key] = project
+
+	return projects
+}
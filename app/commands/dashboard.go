@@ -45,10 +45,15 @@ func Dashboard(p printer.Printer, cfg config.Config, api *services.API) *cli.Com
 				return fmt.Errorf("group: %w", err)
 			}
 
-			p.Println(thisMonthSalary.StringTotalPaid())
-			p.Println(lastMonthSalary.StringTotalPaid())
-			p.Println()
-			p.Println(types.NewMonthInfo(thisMonth, thisMonthSalary, vacations, holidays, history))
+			dashboard := types.Dashboard{
+				ThisMonth: thisMonthSalary,
+				LastMonth: lastMonthSalary,
+				Month:     types.NewMonthInfo(thisMonth, thisMonthSalary, vacations, holidays, history),
+			}
+
+			if err := p.Value(dashboard); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
 
 			return nil
 		},
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	usageDay   = "day in format YYYY-MM-DD or MM-DD, or today, yesterday, -2d, fri"
+	usageMonth = "month in format YYYY-MM or MM, or any day in it, e.g., yesterday"
+)
+
+// dayFlag parses the flag value as a day relative to today, see types.ParseDay.
+func dayFlag(c *cli.Context, name string) (types.Date, error) {
+	date, err := types.ParseDay(c.String(name), types.Today())
+	if err != nil {
+		return date, fmt.Errorf("--%s: %w", name, err)
+	}
+
+	return date, nil
+}
+
+// monthFlag parses the flag value as a month relative to today, see types.ParseMonth.
+// The current month is returned if the flag is not set.
+func monthFlag(c *cli.Context, name string) (types.Date, error) {
+	value := c.String(name)
+	if len(value) == 0 {
+		value = "today"
+	}
+
+	date, err := types.ParseMonth(value, types.Today())
+	if err != nil {
+		return date, fmt.Errorf("--%s: %w", name, err)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/export"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+func Export(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "make documents to share",
+		Subcommands: cli.Commands{
+			ExportReport(p, cfg, api),
+		},
+	}
+}
+
+func ExportReport(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "report",
+		Usage: "summary of the month in Markdown or HTML",
+		Description: "" +
+			"Print the summary of the month to share: hours by the project and by the activity, and the entries\n" +
+			"of each day with their titles and descriptions. The project narrows it down, e.g., for a client:\n\n" +
+			"    vkpm export report --for 2024-05 > 2024-05.md\n" +
+			"    vkpm export report --for 2024-05 --format html --proj egg > egg-2024-05.html",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: flagFor, Aliases: []string{"F"}, DefaultText: "this month", Usage: usageMonth},
+			&cli.StringFlag{Name: flagFormat, Value: export.FormatMarkdown, Usage: "document to make: md or html"},
+			&cli.StringFlag{Name: flagProj, Aliases: []string{"p"}, Usage: "only entries of the project"},
+		},
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "export report")
+			defer end()
+
+			month, err := monthFlag(c, flagFor)
+			if err != nil {
+				return fmt.Errorf("month: %w", err)
+			}
+
+			filter := types.EntriesFilter{}
+			if project := c.String(flagProj); len(project) > 0 {
+				if filter.Project, err = matchProject(ctx, cfg, api, project); err != nil {
+					return fmt.Errorf("match project: %w", err)
+				}
+			}
+
+			history, err := api.History(ctx, month.Year(), month.Month())
+			if err != nil {
+				return fmt.Errorf("history in %d %v: %w", month.Year(), month.Month(), err)
+			}
+
+			if err = export.Write(p.W, c.String(flagFormat), export.NewReport(month, history.Filter(filter))); err != nil {
+				return fmt.Errorf("write: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
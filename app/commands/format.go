@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagFormat = "format"
+)
+
+func formatFlag(item, example string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  flagFormat,
+		Usage: "Go template to print each " + item + " with, or a named format from config, e.g., '" + example + "'",
+	}
+}
+
+// printFormatted prints the items with the --format template if it is given, and the value in the --output otherwise.
+// The template is looked up in the named formats first.
+func printFormatted(c *cli.Context, p printer.Printer, cfg config.Config, value, items interface{}) error {
+	format := c.String(flagFormat)
+	if len(format) == 0 {
+		if err := p.Value(value); err != nil {
+			return fmt.Errorf("print: %w", err)
+		}
+
+		return nil
+	}
+
+	if named, ok := cfg.Formats[format]; ok {
+		format = named
+	}
+
+	if err := p.Format(items, format); err != nil {
+		return fmt.Errorf("--%s: %w", flagFormat, err)
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,34 +11,189 @@ import (
 	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	flagGrep    = "grep"
+	flagMinSpan = "min-span"
+
+	usageDayOrMonth = "day, as for --for in report, or month in format YYYY-MM or MM"
+)
+
+var (
+	errForRange  = errors.New("use either --for or --from and --to")
+	errBadRange  = errors.New("--from is after --to")
+	errFutureDay = errors.New("future is unknown")
 )
 
 func History(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
 	return &cli.Command{
 		Name:  "history",
 		Usage: "show reported hours",
+		Description: "" +
+			"Show the entries reported in the month, or from one day or month to another, e.g., for the Q2:\n\n" +
+			"    vkpm history --from 2024-04 --to 2024-06 --proj egg\n\n" +
+			"Entries can be filtered by the project, the activity, the text in the title or the description,\n" +
+			"and the span.",
 		Flags: []cli.Flag{
-			&cli.TimestampFlag{
-				Name: flagFor, Layout: "2006-01",
-				DefaultText: "this month", Value: cli.NewTimestamp(time.Now()),
-			},
+			&cli.StringFlag{Name: flagFor, Aliases: []string{"F"}, DefaultText: "this month", Usage: usageMonth},
+			&cli.StringFlag{Name: flagFrom, Aliases: []string{"f"}, Usage: "first " + usageDayOrMonth},
+			&cli.StringFlag{Name: flagTo, Aliases: []string{"t"}, DefaultText: "today", Usage: "last " + usageDayOrMonth},
+			&cli.StringFlag{Name: flagProj, Aliases: []string{"p"}, Usage: "only entries of the project"},
+			&cli.StringFlag{Name: flagActivity, Aliases: []string{"a"}, Usage: "only entries of the activity"},
+			&cli.StringFlag{Name: flagGrep, Aliases: []string{"g"}, Usage: "only entries with the text in the title or message"},
+			&cli.DurationFlag{Name: flagMinSpan, Usage: "only entries spanning at least that long, e.g., 1h"},
+			formatFlag("entry", `{{.ReportDate.Format "01-02"}} {{.Project.Name}}: {{.Description}}`),
 		},
 		Before: before.IsHTTPAuthMeet(cfg),
 		Action: func(c *cli.Context) error {
 			ctx, end := th.RegionTask(c.Context, "history")
 			defer end()
 
-			date := c.Timestamp(flagFor)
+			filter, err := entriesFilter(c)
+			if err != nil {
+				return fmt.Errorf("filter: %w", err)
+			}
+
+			if len(filter.Project) > 0 {
+				if filter.Project, err = matchProject(ctx, cfg, api, filter.Project); err != nil {
+					return fmt.Errorf("match project: %w", err)
+				}
+			}
 
-			history, err := api.History(ctx, date.Year(), date.Month())
+			history, err := historyRange(ctx, api, filter.From, filter.To)
 			if err != nil {
-				return fmt.Errorf("history in %d %v: %w", date.Year(), date.Month(), err)
+				return fmt.Errorf("history: %w", err)
 			}
 
-			p.Println(history)
+			history = history.Filter(filter)
 
-			return nil
+			return printFormatted(c, p, cfg, history, history)
 		},
 	}
 }
+
+// entriesFilter makes the filter from the flags. The days are of the --for month, unless --from or --to are given;
+// --to is today by default, and --from is the start of the --to month.
+func entriesFilter(c *cli.Context) (types.EntriesFilter, error) {
+	filter := types.EntriesFilter{
+		Project:  c.String(flagProj),
+		Grep:     c.String(flagGrep),
+		MinSpan:  c.Duration(flagMinSpan),
+		Activity: c.String(flagActivity),
+	}
+
+	if len(filter.Activity) > 0 {
+		entry, err := types.ReportEntry{}.SetActivity(filter.Activity)
+		if err != nil {
+			return filter, fmt.Errorf("activity: %w", err)
+		}
+
+		filter.Activity = entry.Activity
+	}
+
+	if !c.IsSet(flagFrom) && !c.IsSet(flagTo) {
+		month, err := monthFlag(c, flagFor)
+		if err != nil {
+			return filter, fmt.Errorf("month: %w", err)
+		}
+
+		filter.From, filter.To = month, month.AddDate(0, 1, -1)
+
+		return filter, nil
+	}
+
+	if c.IsSet(flagFor) {
+		return filter, errForRange
+	}
+
+	var err error
+
+	if filter.To, err = dayOrMonthFlag(c, flagTo, true); err != nil {
+		return filter, err
+	}
+
+	if !c.IsSet(flagFrom) {
+		filter.From = types.Date{Time: time.Date(filter.To.Year(), filter.To.Month(), 1, 0, 0, 0, 0, filter.To.Location())}
+	} else if filter.From, err = dayOrMonthFlag(c, flagFrom, false); err != nil {
+		return filter, err
+	}
+
+	if filter.From.After(filter.To.Time) {
+		return filter, errBadRange
+	}
+
+	if filter.From.After(types.Today().Time) {
+		return filter, errFutureDay
+	}
+
+	return filter, nil
+}
+
+// matchProject finds the project name the way report does, resolving the aliases first.
+func matchProject(ctx context.Context, cfg config.Config, api *services.API, name string) (string, error) {
+	projects, err := api.Projects(ctx)
+	if err != nil {
+		return "", fmt.Errorf("projects: %w", err)
+	}
+
+	project, err := projects.Match(types.ProjectAliases(cfg.ProjectAliases).Resolve(name))
+	if err != nil {
+		return "", fmt.Errorf("match: %w", err)
+	}
+
+	return project.Name, nil
+}
+
+// dayOrMonthFlag parses the flag value as a day, or as a month to take the first or the last day of.
+func dayOrMonthFlag(c *cli.Context, name string, last bool) (types.Date, error) {
+	if day, err := dayFlag(c, name); err == nil {
+		return day, nil
+	}
+
+	month, err := monthFlag(c, name)
+	if err != nil {
+		return month, err
+	}
+
+	if last {
+		month = month.AddDate(0, 1, -1)
+	}
+
+	return month, nil
+}
+
+// historyRange fetches the entries of the months the days are in, all at once, and returns them in order.
+func historyRange(ctx context.Context, api *services.API, from, to types.Date) (types.ReportEntries, error) {
+	group, gctx := errgroup.WithContext(ctx)
+	months := make([]types.ReportEntries, (to.Year()-from.Year())*12+int(to.Month()-from.Month())+1)
+
+	for i := range months {
+		i, month := i, time.Date(from.Year(), from.Month()+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+
+		group.Go(func() error {
+			history, err := api.History(gctx, month.Year(), month.Month())
+			if err != nil {
+				return fmt.Errorf("history in %d %v: %w", month.Year(), month.Month(), err)
+			}
+
+			months[i] = history
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, fmt.Errorf("group: %w", err)
+	}
+
+	var history types.ReportEntries
+	for _, entries := range months {
+		history = append(history, entries...)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	errNothingToImport = errors.New("nothing to import")
+)
+
+func Import(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "report time tracked in Toggl, Clockify or Timewarrior",
+		ArgsUsage: "<file>",
+		Description: "" +
+			"Report entries from the export of the time tracker: detailed report in CSV or JSON for Toggl\n" +
+			"and Clockify, and timew export for Timewarrior. Times are rounded to 10 minutes, and entries\n" +
+			"shorter than that once rounded, or going over midnight, are skipped. Project names of the tracker,\n" +
+			"or the tags in Timewarrior, are mapped to the projects with vkpm config --alias.\n" +
+			"The plan is shown before reporting:\n\n" +
+			"    vkpm config --alias 'Egg (client)=Egg Inc.'\n" +
+			"    vkpm import --format toggl Toggl_time_entries.csv\n" +
+			"    timew export :week > week.json && vkpm import --format timewarrior week.json\n\n",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: flagFormat, Required: true,
+				Usage: "tracker the file is exported from: toggl, clockify or timewarrior",
+			},
+			&cli.BoolFlag{Name: flagYes, Aliases: []string{"y"}, Usage: "do not ask for confirmation"},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "import")
+			defer end()
+
+			if c.Args().Len() == 0 {
+				return errNoFile
+			}
+
+			tracks, err := importer.ReadTrackerFile(c.String(flagFormat), c.Args().First())
+			if err != nil {
+				return fmt.Errorf("read tracker file: %w", err)
+			}
+
+			records, skipped, err := tracks.Records(types.ProjectAliases(cfg.ProjectAliases))
+			if err != nil {
+				return fmt.Errorf("records: %w", err)
+			}
+
+			for _, skip := range skipped {
+				p.ErrPrintln("entry " + strconv.Itoa(skip.Track.Line) + ": skipped, " + skip.Reason.Error() + ": " +
+					skip.Track.Start.Format("2006-01-02 15:04") + "-" + skip.Track.End.Format("15:04") + " " +
+					skip.Track.Description)
+			}
+
+			if len(records) == 0 {
+				return errNothingToImport
+			}
+
+			label := func(record importer.Record) string { return "entry " + strconv.Itoa(record.Line) }
+			confirm := func(items batch) error {
+				return items.confirm(c, p, fmt.Sprintf("Report %d entries?", len(items)))
+			}
+
+			if _, err = reportRecords(ctx, p, cfg, api, records, label, confirm); err != nil {
+				return fmt.Errorf("report records: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -2,8 +2,15 @@ package commands
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"runtime"
+	"strings"
 
 	"github.com/kudrykv/go-vkpm/app/commands/before"
 	"github.com/kudrykv/go-vkpm/app/config"
@@ -14,10 +21,36 @@ import (
 	"golang.org/x/term"
 )
 
+const (
+	flagUsername      = "username"
+	flagPasswordStdin = "password-stdin"
+
+	envUsername = "VKPM_USERNAME"
+	envPassword = "VKPM_PASSWORD"
+)
+
+var (
+	errNoTerminal = errors.New("not a terminal")
+	errNoUsername = errors.New("no username saved, run vkpm login")
+	errStdinUser  = errors.New("--password-stdin requires --username or " + envUsername)
+	errNoPassword = errors.New("empty password")
+)
+
 func Login(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
 	return &cli.Command{
 		Name:  "login",
 		Usage: "sign in into the system",
+		Description: "" +
+			"Sign in and save the session.\n\n" +
+			"Username is taken from --username, " + envUsername + " or asked for.\n" +
+			"Password is taken from stdin with --password-stdin, " + envPassword + ", the output of\n" +
+			"password_command from the config, or asked for:\n\n" +
+			"    pass show vkpm | vkpm login --username john --password-stdin\n" +
+			"    vkpm config --password-command 'pass show vkpm' && vkpm login --username john\n\n",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: flagUsername, Aliases: []string{"u"}, EnvVars: []string{envUsername}},
+			&cli.BoolFlag{Name: flagPasswordStdin, Usage: "read password from stdin"},
+		},
 
 		Before: before.IsDomainSet(cfg),
 
@@ -25,30 +58,57 @@ func Login(p printer.Printer, cfg config.Config, api *services.API) *cli.Command
 			ctx, end := th.RegionTask(c.Context, "login")
 			defer end()
 
-			reader := bufio.NewReader(os.Stdin)
+			reader := bufio.NewReader(stdin(c))
 
-			p.Print("username: ")
+			username := c.String(flagUsername)
+			if len(username) == 0 {
+				if c.Bool(flagPasswordStdin) {
+					return errStdinUser
+				}
 
-			username, err := reader.ReadString('\n')
-			if err != nil {
-				return fmt.Errorf("read string: %w", err)
+				p.ErrPrint("username: ")
+
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return fmt.Errorf("read string: %w", err)
+				}
+
+				username = strings.TrimSpace(line)
 			}
 
-			p.Print("password: ")
+			var (
+				password string
+				err      error
+			)
+
+			if c.Bool(flagPasswordStdin) {
+				password, err = reader.ReadString('\n')
+				if err != nil && !errors.Is(err, io.EOF) {
+					return fmt.Errorf("read stdin: %w", err)
+				}
 
-			btsPassword, err := term.ReadPassword(0) // 0 is stdin
+				password = strings.TrimRight(password, "\r\n")
+			} else if password, err = unattendedPassword(ctx, cfg); err != nil {
+				return fmt.Errorf("unattended password: %w", err)
+			}
 
-			p.Println()
+			if len(password) == 0 && !c.Bool(flagPasswordStdin) {
+				if password, err = readPassword(p); err != nil {
+					return fmt.Errorf("read password: %w", err)
+				}
+			}
 
-			if err != nil {
-				return fmt.Errorf("read password: %w", err)
+			if len(password) == 0 {
+				return errNoPassword
 			}
 
-			cfg.Cookies, err = api.Login(ctx, username, string(btsPassword))
+			cfg.Cookies, err = api.Login(ctx, username, password)
 			if err != nil {
 				return fmt.Errorf("login: %w", err)
 			}
 
+			cfg.Username = username
+
 			if err = cfg.Write(); err != nil {
 				return fmt.Errorf("write config: %w", err)
 			}
@@ -57,3 +117,101 @@ func Login(p printer.Printer, cfg config.Config, api *services.API) *cli.Command
 		},
 	}
 }
+
+// Credentials provides credentials to renew the expired session: the saved or VKPM_USERNAME username
+// and VKPM_PASSWORD, password_command output or the password asked for in the terminal.
+func Credentials(p printer.Printer, cfg config.Config) services.Credentials {
+	return func(ctx context.Context) (string, string, error) {
+		username := os.Getenv(envUsername)
+		if len(username) == 0 {
+			username = cfg.Username
+		}
+
+		if len(username) == 0 {
+			return "", "", errNoUsername
+		}
+
+		password, err := unattendedPassword(ctx, cfg)
+		if err != nil {
+			return "", "", fmt.Errorf("unattended password: %w", err)
+		}
+
+		if len(password) > 0 {
+			return username, password, nil
+		}
+
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return "", "", fmt.Errorf("ask password: %w", errNoTerminal)
+		}
+
+		p.ErrPrintln("session expired, signing in as " + username)
+
+		if password, err = readPassword(p); err != nil {
+			return "", "", fmt.Errorf("read password: %w", err)
+		}
+
+		return username, password, nil
+	}
+}
+
+// CanRelogin tells if the expired session should be renewed: either the username and the password source
+// are configured, so it goes unattended, or the renewal is asked for and the password could be prompted for.
+func CanRelogin(cfg config.Config, asked bool) bool {
+	if asked {
+		return true
+	}
+
+	username := os.Getenv(envUsername)
+	if len(username) == 0 {
+		username = cfg.Username
+	}
+
+	return len(username) > 0 && (len(os.Getenv(envPassword)) > 0 || len(cfg.PasswordCommand) > 0)
+}
+
+// unattendedPassword returns the password from the environment or the password command.
+// Empty password means neither is set.
+func unattendedPassword(ctx context.Context, cfg config.Config) (string, error) {
+	if password := os.Getenv(envPassword); len(password) > 0 {
+		return password, nil
+	}
+
+	if len(cfg.PasswordCommand) == 0 {
+		return "", nil
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, shell, flag, cfg.PasswordCommand)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %s: %w", cfg.PasswordCommand, strings.TrimSpace(stderr.String()), err)
+	}
+
+	password := strings.SplitN(string(out), "\n", 2)[0]
+	if password = strings.TrimRight(password, "\r"); len(password) == 0 {
+		return "", fmt.Errorf("%s: %w", cfg.PasswordCommand, errNoPassword)
+	}
+
+	return password, nil
+}
+
+func readPassword(p printer.Printer) (string, error) {
+	p.ErrPrint("password: ")
+
+	bts, err := term.ReadPassword(int(os.Stdin.Fd()))
+
+	p.ErrPrintln()
+
+	if err != nil {
+		return "", fmt.Errorf("read password: %w", err)
+	}
+
+	return string(bts), nil
+}
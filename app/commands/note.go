@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/notes"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+func Note(p printer.Printer, cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:      "note",
+		Usage:     "take a note for the report, or list the notes of the day",
+		ArgsUsage: "[text]",
+		Description: "" +
+			"Notes are kept locally, one log per day, and become the report message with report --from-notes.\n" +
+			"Start the note with @project, or use --proj, to split the report by projects later:\n\n" +
+			"    vkpm note 'fixed flaky test'\n" +
+			"    vkpm note '@k4s deployed the release'\n" +
+			"    vkpm note\n" +
+			"    vkpm report -s 8h --from-notes --split\n\n",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: flagProj, Aliases: []string{"p"}, Usage: "tag the note with the project"},
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "today",
+				Usage: "list notes of the " + usageDay,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			_, end := th.RegionTask(c.Context, "note")
+			defer end()
+
+			log := notes.New(cfg.Path())
+
+			if c.Args().Len() == 0 {
+				date, err := dayFlag(c, flagFor)
+				if err != nil {
+					return fmt.Errorf("day: %w", err)
+				}
+
+				list, err := log.Day(date)
+				if err != nil {
+					return fmt.Errorf("day: %w", err)
+				}
+
+				for _, note := range list {
+					p.Println(note)
+				}
+
+				return nil
+			}
+
+			note := notes.Note{
+				At:      types.Today().Time,
+				Project: c.String(flagProj),
+				Text:    strings.Join(c.Args().Slice(), " "),
+			}
+
+			if err := log.Add(note); err != nil {
+				return fmt.Errorf("add: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -5,11 +5,12 @@ import (
 
 	"github.com/kudrykv/go-vkpm/app/commands/before"
 	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/urfave/cli/v2"
 )
 
-func ProjectsList(cfg config.Config, api *services.API) *cli.Command {
+func ProjectsList(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
 	return &cli.Command{
 		Name: "list",
 
@@ -21,7 +22,9 @@ func ProjectsList(cfg config.Config, api *services.API) *cli.Command {
 				return fmt.Errorf("list projects: %w", err)
 			}
 
-			_, _ = fmt.Fprintln(appCtx.App.Writer, projects.String())
+			if err = p.Value(projects); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
 
 			return nil
 		},
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+const (
+	flagYes = "yes"
+)
+
+var (
+	errBadChoice = errors.New("no such choice")
+	errCancelled = errors.New("cancelled")
+)
+
+func confirm(p printer.Printer, r io.Reader, question string) (bool, error) {
+	p.ErrPrint(question + " [y/N]: ")
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read string: %w", err)
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes", nil
+}
+
+// stdin returns the reader of the top-level app, as subcommands get os.Stdin regardless.
+func stdin(c *cli.Context) io.Reader {
+	reader := c.App.Reader
+
+	for _, ctx := range c.Lineage() {
+		if ctx.App != nil && ctx.App.Reader != nil {
+			reader = ctx.App.Reader
+		}
+	}
+
+	return reader
+}
+
+// pickProject lists the projects and asks to choose one by its number.
+func pickProject(p printer.Printer, r io.Reader, projects types.Projects) (types.Project, error) {
+	for i, project := range projects {
+		p.ErrPrintln(strconv.Itoa(i+1) + ") " + project.Name)
+	}
+
+	p.ErrPrint("project: ")
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return types.Project{}, fmt.Errorf("read string: %w", err)
+	}
+
+	choice, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || choice < 1 || choice > len(projects) {
+		return types.Project{}, fmt.Errorf("%s: %w", strings.TrimSpace(answer), errBadChoice)
+	}
+
+	return projects[choice-1], nil
+}
+
+func isTerminal(r io.Reader) bool {
+	file, ok := r.(*os.File)
+
+	return ok && term.IsTerminal(int(file.Fd()))
+}
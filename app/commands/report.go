@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kudrykv/go-vkpm/app/commands/before"
 	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/notes"
 	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/kudrykv/go-vkpm/app/th"
@@ -26,13 +29,22 @@ const (
 	flagActivity = "activity"
 	flagTitle    = "title"
 	flagMessage  = "message"
+	flagDryRun   = "dry-run"
+	flagFill     = "fill"
+	flagTemplate = "template"
+	flagNotes    = "from-notes"
+	flagSplit    = "split"
 )
 
 var (
-	errEmptyProj   = errors.New("empty project")
-	errBadTime     = errors.New("must be rounded to 10 minutes")
-	errRangeOrSpan = errors.New("use only range or span")
-	errNoTime      = errors.New("specify span or time range")
+	errEmptyProj  = errors.New("empty project")
+	errNoMessage  = errors.New("empty message")
+	errNoTemplate = errors.New("no such template in config")
+	errNoNotes    = errors.New("no notes for the day")
+	errNotesMsg   = errors.New("use either message or notes")
+	errSplitNotes = errors.New("split needs --from-notes")
+	errSplitSpan  = errors.New("split needs the span long enough to give each project 10 minutes")
+	errSplitRange = errors.New("split divides the span between the projects, it cannot take the from-to range")
 )
 
 func Report(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
@@ -46,16 +58,27 @@ func Report(p printer.Printer, cfg config.Config, api *services.API) *cli.Comman
 			"Time can be specifed as a span (--span 2h), or a from-to range (--from 10:00 --to 12:00).\n" +
 			"If specified as a span, reports start from 09:00 of the given day, and stack one at each other.\n\n" +
 			"    vkpm report --proj projname --span 2h -m 'dev cli tools'         # 09:00-11:00\n" +
-			"    vkpm report --proj projname --span 2h -m 'doing other dev stuff' # 11:00-13:00\n\n",
+			"    vkpm report --proj projname --span 2h -m 'doing other dev stuff' # 11:00-13:00\n\n" +
+			"Day start, lunch and stacking are set with vkpm config. Spans skip the lunch, so with\n" +
+			"--lunch 12:00-13:00 the second report above is split into 11:00-12:00 and 13:00-14:00.\n" +
+			"With --stacking first-gap a span goes to the first free gap it fits instead of after the latest entry.\n" +
+			"With --stacking fill, or --fill for a single report, a span fills the earliest free gaps of the day\n" +
+			"and is split into several entries if needed.\n\n" +
+			"Project name can be partial, or an alias from config. If it matches several projects equally well,\n" +
+			"you are asked to pick one when run in a terminal.\n\n" +
+			"Recurring reports can be defined as templates in config, and flags override template fields:\n\n" +
+			"    vkpm report --template standup\n" +
+			"    vkpm report --tpl review -m 'PR 42'\n\n" +
+			"Use --dry-run to see the entry and the form to be sent without reporting it.\n\n",
 		Before: before.IsHTTPAuthMeet(cfg),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name: flagProj, Aliases: []string{"p"},
-				Usage: "report for the specified project. Use default if not set",
+				Usage: "report for the specified project or alias. Use default if not set",
 			},
-			&cli.TimestampFlag{
-				Name: flagFor, Layout: "01-02", DefaultText: "today", Aliases: []string{"F"},
-				Usage: "report date, specified in format MM-DD",
+			&cli.StringFlag{
+				Name: flagFor, DefaultText: "today", Aliases: []string{"F"},
+				Usage: "report " + usageDay,
 			},
 			&cli.DurationFlag{
 				Name: flagSpan, DefaultText: "not set", Aliases: []string{"s"},
@@ -79,9 +102,27 @@ func Report(p printer.Printer, cfg config.Config, api *services.API) *cli.Comman
 			},
 			&cli.StringFlag{Name: flagTitle, Aliases: []string{"T"}, Usage: "report title", DefaultText: "project name"},
 			&cli.StringFlag{
-				Name: flagMessage, Aliases: []string{"m"}, Required: true,
+				Name: flagMessage, Aliases: []string{"m"},
 				Usage: "what did you do in the given time frame",
 			},
+			&cli.StringFlag{
+				Name: flagTemplate, Aliases: []string{"tpl"},
+				Usage: "take project, activity, title, span, status and message from the template in config " +
+					"(short --tpl, as -t is --to)",
+			},
+			&cli.BoolFlag{Name: flagDryRun, Usage: "show what would be reported, but do not report"},
+			&cli.BoolFlag{Name: flagFill, Usage: "split the span over the earliest free gaps of the day"},
+			&cli.BoolFlag{Name: flagNotes, Usage: "take the message from the notes of the day, see vkpm note"},
+			&cli.BoolFlag{
+				Name:  flagSplit,
+				Usage: "with --from-notes, report an entry per project the notes are tagged with, splitting the span equally",
+			},
+		},
+		Subcommands: cli.Commands{
+			ReportImport(p, cfg, api),
+			ReportFill(p, cfg, api),
+			ReportSuggest(p, cfg),
+			ReportFromICS(p, cfg, api),
 		},
 		Action: func(c *cli.Context) error {
 			ctx, end := th.RegionTask(c.Context, "report")
@@ -98,35 +139,128 @@ func Report(p printer.Printer, cfg config.Config, api *services.API) *cli.Comman
 				return fmt.Errorf("parse entry: %w", err)
 			}
 
-			today := types.Today()
+			if c.Bool(flagSplit) && !c.Bool(flagNotes) {
+				return errSplitNotes
+			}
+
+			parsed := types.ReportEntries{entry}
+			if c.Bool(flagSplit) {
+				if parsed, err = splitByProject(cfg, entry); err != nil {
+					return fmt.Errorf("split by project: %w", err)
+				}
+			}
+
 			group, cctx := errgroup.WithContext(ctx)
 
-			group.Go(getHistory(cctx, api, today, &history))
+			group.Go(getHistory(cctx, api, entry.ReportDate, &history))
 			group.Go(getProjects(cctx, api, &projects))
 
 			if err = group.Wait(); err != nil {
 				return fmt.Errorf("group: %w", err)
 			}
 
-			if entry, err = entry.UpdateProjectName(projects); err != nil {
-				return fmt.Errorf("fixup project name: %w", err)
+			day, err := workday(cfg)
+			if err != nil {
+				return fmt.Errorf("workday: %w", err)
+			}
+
+			if c.Bool(flagFill) {
+				day.Stacking = types.StackFill
 			}
 
-			if entry, err = entry.AlignTimes(history); err != nil {
-				return fmt.Errorf("align: %w", err)
+			var entries types.ReportEntries
+
+			for _, entry := range parsed {
+				if entry, err = updateProjectName(c, p, cfg, entry, projects); err != nil {
+					return fmt.Errorf("fixup project name: %w", err)
+				}
+
+				aligned, err := entry.AlignTimes(history, day)
+				if err != nil {
+					return fmt.Errorf("align: %w", err)
+				}
+
+				history = append(history, aligned...)
+				entries = append(entries, aligned...)
 			}
 
-			if entry, err = api.Report(c.Context, entry); err != nil {
-				return fmt.Errorf("report: %w", err)
+			if c.Bool(flagDryRun) {
+				return printDryRun(p, entries)
 			}
 
-			p.Println(entry)
+			for _, entry := range entries {
+				if entry, err = api.Report(c.Context, entry); err != nil {
+					return fmt.Errorf("report: %w", err)
+				}
+
+				p.Println(entry)
+			}
 
 			return nil
 		},
 	}
 }
 
+func printDryRun(p printer.Printer, entries types.ReportEntries) error {
+	p.Println("Dry run, nothing is reported.")
+
+	for _, entry := range entries {
+		values, err := entry.URLValues()
+		if err != nil {
+			return fmt.Errorf("url values: %w", err)
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		p.Println()
+		p.Println(entry)
+		p.Println("POST /report/")
+
+		for _, key := range keys {
+			p.Printf("  %-20s %s\n", key+":", strings.Join(values[key], ", "))
+		}
+	}
+
+	return nil
+}
+
+// workday returns the rules from the config to place entries reported with a span.
+func workday(cfg config.Config) (types.Workday, error) {
+	day, err := types.NewWorkday(cfg.Workday.Start, cfg.Workday.Lunch, cfg.Workday.Stacking)
+	if err != nil {
+		return day, fmt.Errorf("new workday: %w", err)
+	}
+
+	return day, nil
+}
+
+// updateProjectName matches the project of the entry, resolving aliases from the config.
+// If the name matches several projects equally well, it asks to pick one when run in a terminal.
+func updateProjectName(
+	c *cli.Context, p printer.Printer, cfg config.Config, entry types.ReportEntry, projects types.Projects,
+) (types.ReportEntry, error) {
+	updated, err := entry.UpdateProjectName(projects, types.ProjectAliases(cfg.ProjectAliases))
+
+	var ambiguous *types.AmbiguousProjectError
+	if !errors.As(err, &ambiguous) || !isTerminal(stdin(c)) {
+		return updated, err
+	}
+
+	project, err := pickProject(p, stdin(c), ambiguous.Candidates)
+	if err != nil {
+		return entry, fmt.Errorf("pick project: %w", err)
+	}
+
+	entry.Project = project
+
+	return entry.UpdateProjectName(types.Projects{project}, nil)
+}
+
 func getProjects(cctx context.Context, api *services.API, projects *types.Projects) func() error {
 	return func() error {
 		var err error
@@ -139,10 +273,45 @@ func getProjects(cctx context.Context, api *services.API, projects *types.Projec
 }
 
 func parseEntry(c *cli.Context, cfg config.Config) (types.ReportEntry, error) {
+	var tpl config.Template
+
+	if name := c.String(flagTemplate); len(name) > 0 {
+		var ok bool
+		if tpl, ok = cfg.Templates[name]; !ok {
+			return types.ReportEntry{}, fmt.Errorf("%s: %w", name, errNoTemplate)
+		}
+	}
+
 	entry := types.ReportEntry{
-		Project:     types.Project{Name: c.String(flagProj)},
-		Name:        c.String(flagTitle),
-		Description: c.String(flagMessage),
+		Project:     types.Project{Name: stringOr(c, flagProj, tpl.Project)},
+		Name:        stringOr(c, flagTitle, tpl.Title),
+		Description: stringOr(c, flagMessage, tpl.Message),
+	}
+
+	var err error
+	if entry.ReportDate, err = dayFlag(c, flagFor); err != nil {
+		return entry, fmt.Errorf("report date: %w", err)
+	}
+
+	if c.Bool(flagNotes) {
+		if c.IsSet(flagMessage) {
+			return entry, errNotesMsg
+		}
+
+		list, err := notes.New(cfg.Path()).Day(entry.ReportDate)
+		if err != nil {
+			return entry, fmt.Errorf("notes: %w", err)
+		}
+
+		if len(list) == 0 {
+			return entry, fmt.Errorf("%s: %w", entry.ReportDate.Format("January 2"), errNoNotes)
+		}
+
+		entry.Description = list.Message()
+	}
+
+	if len(entry.Description) == 0 {
+		return entry, fmt.Errorf("no message provided: %w", errNoMessage)
 	}
 
 	if len(entry.Project.Name) == 0 {
@@ -151,27 +320,26 @@ func parseEntry(c *cli.Context, cfg config.Config) (types.ReportEntry, error) {
 		}
 	}
 
-	var err error
-	if entry, err = entry.SetActivity(c.String(flagActivity)); err != nil {
-		return entry, fmt.Errorf("set activity %s: %w", c.String(flagActivity), err)
+	activity := c.String(flagActivity)
+	if !c.IsSet(flagActivity) && len(tpl.Activity) > 0 {
+		activity = tpl.Activity
 	}
 
-	entry.Status = c.Int(flagStatus)
-	if err := entry.TestStatus(); err != nil {
-		return entry, fmt.Errorf("test status %d: %w", c.Int(flagStatus), err)
+	if entry, err = entry.SetActivity(activity); err != nil {
+		return entry, fmt.Errorf("set activity %s: %w", activity, err)
 	}
 
-	entry.ReportDate = types.Today()
-	if tmp := c.Timestamp(flagFor); tmp != nil && !tmp.IsZero() {
-		entry.ReportDate = types.Date{Time: *tmp}.AddDate(types.Today().Year(), 0, 0)
+	entry.Status = c.Int(flagStatus)
+	if !c.IsSet(flagStatus) && tpl.Status != nil {
+		entry.Status = *tpl.Status
 	}
 
-	if entry.Span = c.Duration(flagSpan); entry.Span > 0 {
-		if entry.Span != entry.Span.Round(10*time.Minute) {
-			return entry, fmt.Errorf("span %v: %w", entry.Span, errBadTime)
-		}
+	if err := entry.TestStatus(); err != nil {
+		return entry, fmt.Errorf("test status %d: %w", entry.Status, err)
 	}
 
+	entry.Span = c.Duration(flagSpan)
+
 	if tmp := c.Timestamp(flagFrom); tmp != nil && !tmp.IsZero() {
 		entry.StartTime = *tmp
 	}
@@ -180,17 +348,77 @@ func parseEntry(c *cli.Context, cfg config.Config) (types.ReportEntry, error) {
 		entry.EndTime = *tmp
 	}
 
-	if err := entry.TestBrokenRange(); err != nil {
-		return entry, fmt.Errorf("broken range: %w", err)
+	if entry.IsSpanAndRangeAbsent() {
+		entry.Span = tpl.Span
 	}
 
-	if entry.IsSpanAndRangePresent() {
-		return entry, fmt.Errorf("both range and span defined: %w", errRangeOrSpan)
+	if err := entry.TestTime(); err != nil {
+		return entry, fmt.Errorf("test time: %w", err)
 	}
 
-	if entry.IsSpanAndRangeAbsent() {
-		return entry, fmt.Errorf("no range nor span defined: %w", errNoTime)
+	return entry, nil
+}
+
+// splitByProject makes an entry per project the notes of the day are tagged with, splitting the span equally.
+// Untagged notes go to the project of the entry.
+func splitByProject(cfg config.Config, entry types.ReportEntry) (types.ReportEntries, error) {
+	list, err := notes.New(cfg.Path()).Day(entry.ReportDate)
+	if err != nil {
+		return nil, fmt.Errorf("notes: %w", err)
 	}
 
-	return entry, nil
+	if !entry.IsEmptyRange() {
+		return nil, errSplitRange
+	}
+
+	groups := list.ByProject(entry.Project.Name)
+
+	spans := splitSpan(entry.Span, len(groups))
+	if spans == nil {
+		return nil, errSplitSpan
+	}
+
+	entries := make(types.ReportEntries, 0, len(groups))
+
+	for i, group := range groups {
+		split := entry
+		split.Project = types.Project{Name: group[0].Project}
+		split.Description = group.Message()
+		split.Span = spans[i]
+
+		entries = append(entries, split)
+	}
+
+	return entries, nil
+}
+
+// splitSpan splits the span into n equal parts rounded to 10 minutes, the last one taking the rest.
+// It returns nil if there is not enough time to give each part 10 minutes.
+func splitSpan(span time.Duration, n int) []time.Duration {
+	if n == 0 {
+		return nil
+	}
+
+	share := (span / time.Duration(n)).Truncate(10 * time.Minute)
+	if share == 0 {
+		return nil
+	}
+
+	spans := make([]time.Duration, n)
+	for i := range spans {
+		spans[i] = share
+	}
+
+	spans[n-1] = span - share*time.Duration(n-1)
+
+	return spans
+}
+
+// stringOr returns the flag value, or the fallback if the flag is empty.
+func stringOr(c *cli.Context, name, fallback string) string {
+	if value := c.String(name); len(value) > 0 {
+		return value
+	}
+
+	return fallback
 }
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	errNoTemplateSet = errors.New("specify template to fill the days with")
+)
+
+func ReportFill(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "fill",
+		Usage: "report the template for each working day with nothing reported",
+		Description: "" +
+			"Find working days of the month with nothing reported, skipping weekends, holidays and vacations,\n" +
+			"and report the template from config for each of them. The plan is shown before reporting:\n\n" +
+			"    vkpm report fill --tpl regular\n" +
+			"    vkpm report fill --for 2021-04 --tpl regular --yes\n\n",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "this month",
+				Usage: "month to fill, " + usageMonth,
+			},
+			&cli.StringFlag{Name: flagTemplate, Aliases: []string{"tpl"}, Usage: "template to report for each day"},
+			&cli.BoolFlag{Name: flagYes, Aliases: []string{"y"}, Usage: "do not ask for confirmation"},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "report fill")
+			defer end()
+
+			name := c.String(flagTemplate)
+			if len(name) == 0 {
+				return errNoTemplateSet
+			}
+
+			tpl, ok := cfg.Templates[name]
+			if !ok {
+				return fmt.Errorf("%s: %w", name, errNoTemplate)
+			}
+
+			month, err := monthFlag(c, flagFor)
+			if err != nil {
+				return fmt.Errorf("month: %w", err)
+			}
+
+			var (
+				history   types.ReportEntries
+				vacations types.Vacations
+				holidays  types.Holidays
+			)
+
+			group, cctx := errgroup.WithContext(ctx)
+
+			group.Go(getHistory(cctx, api, month, &history))
+			group.Go(getVacationsHolidays(cctx, api, month, &vacations, &holidays))
+
+			if err = group.Wait(); err != nil {
+				return fmt.Errorf("group: %w", err)
+			}
+
+			// days are counted up to the moment: the end of the past month, or today in this one
+			moment := month.AddDate(0, 1, -1)
+			if today := types.Today(); moment.After(today.Time) {
+				moment = today
+			}
+
+			var days types.Dates
+			if !moment.Before(month.Time) {
+				days = types.NewMonthInfo(moment, types.Salary{}, vacations, holidays, history).Unreported()
+			}
+
+			if len(days) == 0 {
+				p.Println("Nothing to fill in " + month.Format("January 2006"))
+
+				return nil
+			}
+
+			records := make(importer.Records, 0, len(days))
+			for _, date := range days {
+				records = append(records, templateRecord(tpl, date))
+			}
+
+			label := func(record importer.Record) string {
+				date, _ := time.Parse("2006-01-02", record.Date)
+
+				return date.Format("Monday, 02")
+			}
+			confirm := func(items batch) error {
+				return items.confirm(c, p, fmt.Sprintf("Report %d days?", len(items)))
+			}
+
+			if _, err = reportRecords(ctx, p, cfg, api, records, label, confirm); err != nil {
+				return fmt.Errorf("report records: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// templateRecord makes the record of the template for the date, to get the defaults the records get.
+func templateRecord(tpl config.Template, date types.Date) importer.Record {
+	record := importer.Record{
+		Date:     date.Format("2006-01-02"),
+		Project:  tpl.Project,
+		Activity: tpl.Activity,
+		Title:    tpl.Title,
+		Message:  tpl.Message,
+	}
+
+	if tpl.Span > 0 {
+		record.Span = tpl.Span.String()
+	}
+
+	if tpl.Status != nil {
+		record.Status = strconv.Itoa(*tpl.Status)
+	}
+
+	return record
+}
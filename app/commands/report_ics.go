@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/ics"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	errNoCalendar = errors.New("specify calendar file")
+)
+
+func ReportFromICS(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:      "from-ics",
+		Usage:     "report meetings of the day from iCalendar file",
+		ArgsUsage: "<calendar.ics>",
+		Description: "" +
+			"Report events of the day from the calendar exported by the calendar app, recurring ones included.\n" +
+			"Times are rounded to 10 minutes, and all-day, canceled and overlapping events are skipped.\n" +
+			"Meetings go to the project and activity of the first rule in config with the match found\n" +
+			"in the summary, or to the default project as management. The plan is shown before reporting:\n\n" +
+			"    meetings:\n" +
+			"      - match: focus time\n" +
+			"        skip: true\n" +
+			"      - match: egg\n" +
+			"        project: egginc\n" +
+			"      - match: interview\n" +
+			"        project: hiring\n" +
+			"        activity: analysis\n\n" +
+			"    vkpm report from-ics --for 05-13 calendar.ics\n\n",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "today",
+				Usage: "report meetings of the " + usageDay,
+			},
+			&cli.BoolFlag{Name: flagYes, Aliases: []string{"y"}, Usage: "do not ask for confirmation"},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "report from-ics")
+			defer end()
+
+			if c.Args().Len() == 0 {
+				return errNoCalendar
+			}
+
+			date, err := dayFlag(c, flagFor)
+			if err != nil {
+				return fmt.Errorf("day: %w", err)
+			}
+
+			events, err := readEvents(c.Args().First(), date)
+			if err != nil {
+				return fmt.Errorf("read events: %w", err)
+			}
+
+			day, err := workday(cfg)
+			if err != nil {
+				return fmt.Errorf("workday: %w", err)
+			}
+
+			items := make(batch, 0, len(events))
+
+			for _, event := range events {
+				record, ok, err := meetingRecord(cfg.Meetings, event)
+				if !ok {
+					continue
+				}
+
+				item := batchItem{label: event.Start.Format("15:04") + "-" + event.End.Format("15:04"), err: err}
+				if item.err == nil {
+					item.entry, item.err = record.Entry(cfg.DefaultProject)
+				}
+
+				items = append(items, item)
+			}
+
+			if len(items) == 0 {
+				p.Println("No meetings on " + date.Format("Monday, 02 January 2006"))
+
+				return nil
+			}
+
+			if items, err = items.prepare(ctx, api, day, types.ProjectAliases(cfg.ProjectAliases)); err != nil {
+				return fmt.Errorf("prepare: %w", err)
+			}
+
+			// a meeting over the one reported or already there is skipped, not failing the rest
+			if items = items.skip(p, types.ErrOverlaps); len(items) == 0 {
+				p.Println("No meetings left to report on " + date.Format("Monday, 02 January 2006"))
+
+				return nil
+			}
+
+			if err = items.check(p); err != nil {
+				return fmt.Errorf("check: %w", err)
+			}
+
+			if err = items.confirm(c, p, fmt.Sprintf("Report %d meetings?", len(items))); err != nil {
+				return fmt.Errorf("confirm: %w", err)
+			}
+
+			if err = items.report(ctx, p, api); err != nil {
+				return fmt.Errorf("report: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func readEvents(path string, date types.Date) (ics.Events, error) {
+	sock, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer func() { _ = sock.Close() }()
+
+	cal, err := ics.Read(sock)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	events, err := cal.On(date)
+	if err != nil {
+		return nil, fmt.Errorf("on %s: %w", date.Format("2006-01-02"), err)
+	}
+
+	return events, nil
+}
+
+// meetingRecord makes the record of the event, rounded to 10 minutes and taking at least 10 minutes,
+// with the project and activity of the first matching rule. It is false if the rule skips the event.
+func meetingRecord(rules []config.Meeting, event ics.Event) (importer.Record, bool, error) {
+	record := importer.Record{Activity: types.ActivityManagement, Message: event.Summary}
+	summary := strings.ToLower(event.Summary)
+
+	for _, rule := range rules {
+		if !strings.Contains(summary, strings.ToLower(rule.Match)) {
+			continue
+		}
+
+		if rule.Skip {
+			return record, false, nil
+		}
+
+		record.Project = rule.Project
+		if len(rule.Activity) > 0 {
+			record.Activity = rule.Activity
+		}
+
+		break
+	}
+
+	timed, err := record.WithTimes(event.Start, event.End)
+	if errors.Is(err, importer.ErrTooShort) {
+		timed, err = record.WithTimes(event.Start, event.Start.Add(10*time.Minute))
+	}
+
+	if err != nil {
+		return record, true, fmt.Errorf("with times: %w", err)
+	}
+
+	return timed, true, nil
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/urfave/cli/v2"
+)
+
+var errNoFile = errors.New("specify file to import")
+
+func ReportImport(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "report many entries from YAML or CSV file",
+		ArgsUsage: "<file|->",
+		Description: "" +
+			"Report entries listed in the file. Each entry has date (YYYY-MM-DD or as in report --for),\n" +
+			"project, activity, span or from and to, status, title and message. Same defaults as in report apply.\n" +
+			"The whole file is checked first, and nothing is reported if any entry is invalid.\n" +
+			"Use - to read YAML from stdin, e.g., from report suggest.\n\n" +
+			"    - date: 2021-05-13\n" +
+			"      project: egg\n" +
+			"      span: 2h\n" +
+			"      message: dealing with email\n\n" +
+			"CSV file names the columns in the header:\n\n" +
+			"    date,project,from,to,message\n" +
+			"    2021-05-13,egg,10:00,12:00,dealing with email\n\n",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "report import")
+			defer end()
+
+			if c.Args().Len() == 0 {
+				return errNoFile
+			}
+
+			var (
+				records importer.Records
+				err     error
+			)
+
+			if path := c.Args().First(); path == "-" {
+				records, err = importer.ReadYAML(stdin(c))
+			} else {
+				records, err = importer.ReadFile(path)
+			}
+
+			if err != nil {
+				return fmt.Errorf("read: %w", err)
+			}
+
+			line := func(record importer.Record) string { return "line " + strconv.Itoa(record.Line) }
+			if _, err = reportRecords(ctx, p, cfg, api, records, line, nil); err != nil {
+				return fmt.Errorf("report records: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
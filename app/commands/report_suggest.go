@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/gitlog"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	errNoCommits = errors.New("no commits")
+)
+
+func ReportSuggest(p printer.Printer, cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "suggest",
+		Usage: "suggest entries for the day from the commits in local git repos",
+		Description: "" +
+			"Read subjects of the commits made on the day in each repo and print an entry per repo with them\n" +
+			"as the message, in the format report import reads. The span is split equally between the repos.\n" +
+			"Projects come from repos set with vkpm config --repo, by the path or the directory name,\n" +
+			"and the default project is used for the rest. Edit the entries, or report them right away:\n\n" +
+			"    vkpm report suggest --repo ~/src/egg --repo ~/src/k4s --for yesterday > day.yml\n" +
+			"    vkpm report import day.yml\n" +
+			"    vkpm report suggest | vkpm report import -\n\n",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{Name: flagRepo, Aliases: []string{"r"}, Usage: "git repo to read", DefaultText: "."},
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "today",
+				Usage: "suggest for the " + usageDay,
+			},
+			&cli.StringFlag{
+				Name: flagAuthor, Usage: "commits author",
+				DefaultText: "git_author from config, or git user.email of the repo",
+			},
+			&cli.DurationFlag{
+				Name: flagSpan, Aliases: []string{"s"}, Value: 8 * time.Hour,
+				Usage: "time to split between the repos",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "report suggest")
+			defer end()
+
+			date, err := dayFlag(c, flagFor)
+			if err != nil {
+				return fmt.Errorf("day: %w", err)
+			}
+
+			repos := c.StringSlice(flagRepo)
+			if len(repos) == 0 {
+				repos = []string{"."}
+			}
+
+			var (
+				records  importer.Records
+				subjects [][]string
+			)
+
+			for _, repo := range repos {
+				author := stringOr(c, flagAuthor, cfg.GitAuthor)
+				if len(author) == 0 {
+					if author, err = gitlog.Author(ctx, repo); err != nil {
+						return fmt.Errorf("%s: author: %w", repo, err)
+					}
+				}
+
+				commits, err := gitlog.Log(ctx, repo, author, date)
+				if err != nil {
+					return fmt.Errorf("%s: log: %w", repo, err)
+				}
+
+				if len(commits) == 0 {
+					continue
+				}
+
+				project, err := repoProject(cfg.Repos, repo)
+				if err != nil {
+					return fmt.Errorf("%s: project: %w", repo, err)
+				}
+
+				records = append(records, importer.Record{Date: date.Format("2006-01-02"), Project: project})
+				subjects = append(subjects, commits.Subjects())
+			}
+
+			if len(records) == 0 {
+				return fmt.Errorf("%s: %w", date.Format("2006-01-02"), errNoCommits)
+			}
+
+			spans := splitSpan(c.Duration(flagSpan), len(records))
+			if spans == nil {
+				return errSplitSpan
+			}
+
+			for i := range records {
+				records[i].Span = formatSpan(spans[i])
+				records[i].Message = strings.Join(subjects[i], "\n")
+			}
+
+			if err = importer.WriteYAML(p.W, records); err != nil {
+				return fmt.Errorf("write yaml: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// repoProject finds the project of the repo by its absolute path first, then by the directory name.
+// It is empty if the repo is not set, so the default project is used.
+func repoProject(repos map[string]string, repo string) (string, error) {
+	path, err := filepath.Abs(repo)
+	if err != nil {
+		return "", fmt.Errorf("abs: %w", err)
+	}
+
+	if project, ok := repos[path]; ok {
+		return project, nil
+	}
+
+	return repos[filepath.Base(path)], nil
+}
+
+// formatSpan drops zero minutes and seconds, e.g., 2h instead of 2h0m0s.
+func formatSpan(span time.Duration) string {
+	s := strings.TrimSuffix(span.String(), "0s")
+
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
@@ -21,7 +21,8 @@ func Stat(p printer.Printer, cfg config.Config, api *services.API) *cli.Command
 		Name:  "stat",
 		Usage: "show money and hour stat for the given year",
 		Flags: []cli.Flag{
-			&cli.IntFlag{Name: flagFor, Usage: "year", Value: time.Now().Year()},
+			&cli.IntFlag{Name: flagFor, Usage: "year", Value: types.Today().Year()},
+			formatFlag("month", "{{.Month}}: {{hours .History.Duration}}h, ${{.Salary.Paid}}"),
 		},
 		Before: before.IsHTTPAuthMeet(cfg),
 		Action: func(c *cli.Context) error {
@@ -31,7 +32,7 @@ func Stat(p printer.Printer, cfg config.Config, api *services.API) *cli.Command
 			startMonth := time.January
 			endMonth := time.December
 			year := c.Int(flagFor)
-			now := time.Now()
+			now := types.Today()
 
 			if now.Year() < year {
 				return fmt.Errorf("future is unknown")
@@ -60,13 +61,13 @@ func Stat(p printer.Printer, cfg config.Config, api *services.API) *cli.Command
 			salaries := salariesChanToSlice(salariesChan)
 			histories := historiesChanToSlice(historiesChan)
 
-			p.Println(types.StatSalaryHistory{
+			stat := types.StatSalaryHistory{
 				Year: year, Salaries: salaries, Histories: histories,
 				StartMonth: startMonth,
 				EndMonth:   endMonth,
-			})
+			}
 
-			return nil
+			return printFormatted(c, p, cfg, stat, stat.Months())
 		},
 	}
 }
@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/timer"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagAt    = "at"
+	flagLater = "later"
+)
+
+var (
+	errNothingPending = errors.New("nothing to report")
+)
+
+func Timer(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "timer",
+		Usage: "track time locally and report it once stopped",
+		Description: "" +
+			"Start the timer when starting the work, and stop it when done. The time is rounded to 10 minutes\n" +
+			"and reported on stop, or kept to be reported later with --later:\n\n" +
+			"    vkpm timer start -p egg -m 'fixing the login'\n" +
+			"    vkpm timer stop\n" +
+			"    vkpm timer stop --later && vkpm timer flush\n\n",
+		Subcommands: cli.Commands{
+			TimerStart(p, cfg),
+			TimerStop(p, cfg, api),
+			TimerStatus(p, cfg),
+			TimerCancel(p, cfg),
+			TimerFlush(p, cfg, api),
+		},
+	}
+}
+
+func TimerStart(p printer.Printer, cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "start",
+		Usage: "start the timer",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: flagProj, Aliases: []string{"p"}, Usage: "project or alias. Use default if not set"},
+			&cli.StringFlag{Name: flagActivity, Aliases: []string{"a"}, Usage: "activity, development if not set"},
+			&cli.StringFlag{Name: flagTitle, Aliases: []string{"T"}, Usage: "report title", DefaultText: "project name"},
+			&cli.StringFlag{Name: flagMessage, Aliases: []string{"m"}, Usage: "what are you doing, can be set on stop"},
+			&cli.StringFlag{Name: flagAt, Usage: "start time in format HH:MM", DefaultText: "now"},
+		},
+		Action: func(c *cli.Context) error {
+			_, end := th.RegionTask(c.Context, "timer start")
+			defer end()
+
+			started, err := atFlag(c)
+			if err != nil {
+				return fmt.Errorf("at: %w", err)
+			}
+
+			state := timer.State{
+				Project:  c.String(flagProj),
+				Activity: c.String(flagActivity),
+				Title:    c.String(flagTitle),
+				Message:  c.String(flagMessage),
+				Started:  started,
+			}
+
+			if err = timer.New(cfg.Path()).Start(state); err != nil {
+				return fmt.Errorf("start: %w", err)
+			}
+
+			p.Println("Started at " + started.Format("15:04"))
+
+			return nil
+		},
+	}
+}
+
+func TimerStop(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:   "stop",
+		Usage:  "stop the timer and report the time",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: flagMessage, Aliases: []string{"m"}, Usage: "what did you do, overrides the one from start"},
+			&cli.StringFlag{Name: flagAt, Usage: "stop time in format HH:MM", DefaultText: "now"},
+			&cli.BoolFlag{Name: flagLater, Usage: "keep the time to report later with timer flush"},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "timer stop")
+			defer end()
+
+			stopped, err := atFlag(c)
+			if err != nil {
+				return fmt.Errorf("at: %w", err)
+			}
+
+			tmr := timer.New(cfg.Path())
+
+			state, err := tmr.State()
+			if err != nil {
+				return fmt.Errorf("state: %w", err)
+			}
+
+			if state == nil {
+				return timer.ErrNotRunning
+			}
+
+			if c.IsSet(flagMessage) {
+				state.Message = c.String(flagMessage)
+			}
+
+			record, err := state.Record(stopped)
+			if err != nil {
+				return fmt.Errorf("record: %w", err)
+			}
+
+			if _, err = record.Entry(cfg.DefaultProject); err != nil {
+				return fmt.Errorf("entry: %w", err)
+			}
+
+			if c.Bool(flagLater) {
+				if err = tmr.Keep(record); err != nil {
+					return fmt.Errorf("keep: %w", err)
+				}
+
+				p.Println("Kept " + record.From + "-" + record.To + " to report later with vkpm timer flush")
+			} else if _, err = reportRecords(ctx, p, cfg, api, importer.Records{record}, timerLabel, nil); err != nil {
+				return fmt.Errorf("report records: %w", err)
+			}
+
+			if _, err = tmr.Stop(); err != nil {
+				return fmt.Errorf("stop: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func TimerStatus(p printer.Printer, cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "status",
+		Usage: "show the running timer and the time kept to report later",
+		Action: func(c *cli.Context) error {
+			_, end := th.RegionTask(c.Context, "timer status")
+			defer end()
+
+			tmr := timer.New(cfg.Path())
+
+			state, err := tmr.State()
+			if err != nil {
+				return fmt.Errorf("state: %w", err)
+			}
+
+			if state == nil {
+				p.Println("Timer is not running")
+			} else {
+				running := time.Since(state.Started).Truncate(time.Minute)
+				p.Println("Running for " + running.String() + " since " + state.Started.Format("15:04") +
+					": " + state.Project + ", " + state.Message)
+			}
+
+			pending, err := tmr.Pending()
+			if err != nil {
+				return fmt.Errorf("pending: %w", err)
+			}
+
+			for _, record := range pending {
+				p.Println("Pending " + record.Date + " " + record.From + "-" + record.To + ": " +
+					record.Project + ", " + record.Message)
+			}
+
+			return nil
+		},
+	}
+}
+
+func TimerCancel(p printer.Printer, cfg config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "cancel",
+		Usage: "stop the timer without reporting",
+		Action: func(c *cli.Context) error {
+			_, end := th.RegionTask(c.Context, "timer cancel")
+			defer end()
+
+			state, err := timer.New(cfg.Path()).Stop()
+			if err != nil {
+				return fmt.Errorf("stop: %w", err)
+			}
+
+			p.Println("Cancelled the timer started at " + state.Started.Format("15:04"))
+
+			return nil
+		},
+	}
+}
+
+func TimerFlush(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:   "flush",
+		Usage:  "report the time kept to report later",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "timer flush")
+			defer end()
+
+			tmr := timer.New(cfg.Path())
+
+			pending, err := tmr.Pending()
+			if err != nil {
+				return fmt.Errorf("pending: %w", err)
+			}
+
+			if len(pending) == 0 {
+				return errNothingPending
+			}
+
+			items, reportErr := reportRecords(ctx, p, cfg, api, pending, timerLabel, nil)
+
+			// keep what was not reported: everything if nothing was, or the failed ones
+			left := pending
+			if items != nil && !errors.Is(reportErr, errBatchInvalid) {
+				left = nil
+
+				for i, item := range items {
+					if item.err != nil {
+						left = append(left, pending[i])
+					}
+				}
+			}
+
+			if err = tmr.SetPending(left); err != nil {
+				return fmt.Errorf("set pending: %w", err)
+			}
+
+			if reportErr != nil {
+				return fmt.Errorf("report records: %w", reportErr)
+			}
+
+			return nil
+		},
+	}
+}
+
+func timerLabel(record importer.Record) string {
+	return record.Date + " " + record.From + "-" + record.To
+}
+
+// atFlag returns today at the time from the flag, or now if the flag is not set.
+func atFlag(c *cli.Context) (time.Time, error) {
+	now := time.Now()
+	if !c.IsSet(flagAt) {
+		return now, nil
+	}
+
+	at, err := time.ParseInLocation("15:04", c.String(flagAt), now.Location())
+	if err != nil {
+		return now, fmt.Errorf("parse: %w", err)
+	}
+
+	return time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, now.Location()), nil
+}
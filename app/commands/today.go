@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+)
+
+func Today(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "today",
+		Usage: "show entries of today, free slots and hours left",
+		Description: "" +
+			"Show today's entries in the timeline order with the lunch and the free slots of the workday between them,\n" +
+			"the hours reported against 8, and where the next report with a span would start.",
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "today")
+			defer end()
+
+			day, err := workday(cfg)
+			if err != nil {
+				return fmt.Errorf("workday: %w", err)
+			}
+
+			today := types.Today()
+
+			history, err := api.History(ctx, today.Year(), today.Month())
+			if err != nil {
+				return fmt.Errorf("history in %d %v: %w", today.Year(), today.Month(), err)
+			}
+
+			if err = p.Value(types.NewDayInfo(today, history, day)); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/eliukblau/pixterm/pkg/ansimage"
 	"github.com/kudrykv/go-vkpm/app/commands/before"
 	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/kudrykv/go-vkpm/app/types"
 	"github.com/urfave/cli/v2"
@@ -21,7 +22,7 @@ const (
 	fDim     = "dim"
 )
 
-func UsersInfo(cfg config.Config, api *services.API) *cli.Command {
+func UsersInfo(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
 	var (
 		id      int
 		search  string
@@ -58,7 +59,7 @@ func UsersInfo(cfg config.Config, api *services.API) *cli.Command {
 				}
 
 				if len(persons) > 1 {
-					_, _ = fmt.Fprintln(c.App.Writer, persons)
+					p.Println(persons)
 
 					return fmt.Errorf("%s: %w", search, errors.New("found multiple users"))
 				}
@@ -71,9 +72,12 @@ func UsersInfo(cfg config.Config, api *services.API) *cli.Command {
 				return fmt.Errorf("person info: %w", err)
 			}
 
-			_, _ = fmt.Fprintln(c.App.Writer, person)
+			if err = p.Value(person); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
 
-			if c.Bool(fWithPic) {
+			// the picture would break structured output
+			if c.Bool(fWithPic) && p.IsText() {
 				bts, err := api.GetPicture(c.Context, person.PhotoURL)
 				if err != nil {
 					return fmt.Errorf("get picture: %w", err)
@@ -88,7 +92,7 @@ func UsersInfo(cfg config.Config, api *services.API) *cli.Command {
 					return fmt.Errorf("image from url: %w", err)
 				}
 
-				_, _ = fmt.Fprintln(c.App.Writer, ansImage.Render())
+				p.Println(ansImage.Render())
 			}
 
 			return nil
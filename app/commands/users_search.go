@@ -9,6 +9,7 @@ import (
 
 	"github.com/kudrykv/go-vkpm/app/commands/before"
 	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/kudrykv/go-vkpm/app/th"
 	"github.com/kudrykv/go-vkpm/app/types"
@@ -26,7 +27,7 @@ const (
 	byBirthday = "bday"
 )
 
-func UsersSearch(cfg config.Config, api *services.API) *cli.Command {
+func UsersSearch(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
 	return &cli.Command{
 		Name:  "search",
 		Usage: "",
@@ -40,6 +41,7 @@ func UsersSearch(cfg config.Config, api *services.API) *cli.Command {
 			},
 			&cli.StringFlag{Name: fTeam, Usage: "filter by team name"},
 			&cli.StringFlag{Name: fName, Usage: "filter by user name"},
+			formatFlag("user", "{{.Name}} ({{.Team}})"),
 		},
 
 		Before: before.IsHTTPAuthMeet(cfg),
@@ -86,9 +88,7 @@ func UsersSearch(cfg config.Config, api *services.API) *cli.Command {
 
 			sort.Slice(persons, sortingPersons(sortByItems, persons))
 
-			_, _ = fmt.Fprintln(c.App.Writer, persons)
-
-			return nil
+			return printFormatted(c, p, cfg, persons, persons)
 		},
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/kudrykv/go-vkpm/app/printer"
 	"github.com/kudrykv/go-vkpm/app/services"
 	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
 	"github.com/urfave/cli/v2"
 )
 
@@ -29,11 +30,9 @@ func Vacations(p printer.Printer, cfg config.Config, api *services.API) *cli.Com
 				return fmt.Errorf("vacations holidays: %w", err)
 			}
 
-			_ = paidDays
-			_ = vacations
-
-			p.Printf("%v day(s) of paid vacations left\n\n", paidDays)
-			p.Println(vacations)
+			if err = p.Value(types.VacationsBalance{PaidDaysLeft: paidDays, Vacations: vacations}); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
 
 			return nil
 		},
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kudrykv/go-vkpm/app/commands/before"
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/printer"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+func Week(p printer.Printer, cfg config.Config, api *services.API) *cli.Command {
+	return &cli.Command{
+		Name:  "week",
+		Usage: "show hours by the project and the day of the week",
+		Description: "" +
+			"Show the timesheet of the week: hours reported for each project on each day, totals of the days,\n" +
+			"and the total of the week against the hours expected, which excludes holidays and vacations.\n" +
+			"Working days up to today with less than 8 hours reported are highlighted.\n\n" +
+			"    vkpm week\n" +
+			"    vkpm week --for 2024-W19\n" +
+			"    vkpm week --for -1w",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: flagFor, Aliases: []string{"F"}, DefaultText: "this week",
+				Usage: "week in format YYYY-Www or Www, or any day in it, e.g., -1w",
+			},
+		},
+		Before: before.IsHTTPAuthMeet(cfg),
+		Action: func(c *cli.Context) error {
+			ctx, end := th.RegionTask(c.Context, "week")
+			defer end()
+
+			monday, err := types.ParseWeek(c.String(flagFor), types.Today())
+			if err != nil {
+				return fmt.Errorf("--%s: %w", flagFor, err)
+			}
+
+			var (
+				history   types.ReportEntries
+				vacations types.Vacations
+				holidays  types.Holidays
+
+				sunday = monday.AddDate(0, 0, 6)
+			)
+
+			group, gctx := errgroup.WithContext(ctx)
+
+			group.Go(func() error {
+				var err error
+				if history, err = historyRange(gctx, api, monday, sunday); err != nil {
+					return fmt.Errorf("history: %w", err)
+				}
+
+				return nil
+			})
+
+			group.Go(func() error {
+				var err error
+				if vacations, holidays, err = vacationsHolidaysRange(gctx, api, monday, sunday); err != nil {
+					return fmt.Errorf("vacations holidays: %w", err)
+				}
+
+				return nil
+			})
+
+			if err = group.Wait(); err != nil {
+				return fmt.Errorf("group: %w", err)
+			}
+
+			if err = p.Value(types.NewWeek(monday, history, vacations, holidays)); err != nil {
+				return fmt.Errorf("print: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// vacationsHolidaysRange gets the vacations and the holidays of the years the days are in.
+func vacationsHolidaysRange(
+	ctx context.Context, api *services.API, from, to types.Date,
+) (types.Vacations, types.Holidays, error) {
+	var (
+		vacations types.Vacations
+		holidays  types.Holidays
+	)
+
+	for year := from.Year(); year <= to.Year(); year++ {
+		_, yearVacations, yearHolidays, err := api.VacationsHolidays(ctx, year)
+		if err != nil {
+			return nil, nil, fmt.Errorf("vacations in %d: %w", year, err)
+		}
+
+		vacations = append(vacations, yearVacations...)
+		holidays = append(holidays, yearHolidays...)
+	}
+
+	return vacations, holidays, nil
+}
@@ -13,10 +13,19 @@ import (
 )
 
 type Config struct {
-	Domain         string        `yaml:"domain"`
-	DefaultProject string        `yaml:"default_project"`
-	Cookies        Cookies       `yaml:"cookies"`
-	HTTPTimeout    time.Duration `yaml:"http_timeout"`
+	Domain          string              `yaml:"domain"`
+	DefaultProject  string              `yaml:"default_project"`
+	Username        string              `yaml:"username"`
+	PasswordCommand string              `yaml:"password_command"`
+	Cookies         Cookies             `yaml:"cookies"`
+	HTTPTimeout     time.Duration       `yaml:"http_timeout"`
+	Workday         Workday             `yaml:"workday,omitempty"`
+	ProjectAliases  map[string]string   `yaml:"project_aliases,omitempty"`
+	Templates       map[string]Template `yaml:"templates,omitempty"`
+	Repos           map[string]string   `yaml:"repos,omitempty"`
+	GitAuthor       string              `yaml:"git_author,omitempty"`
+	Meetings        []Meeting           `yaml:"meetings,omitempty"`
+	Formats         map[string]string   `yaml:"formats,omitempty"`
 
 	path string
 	name string
@@ -27,6 +36,33 @@ type Cookies struct {
 	SessionID string `yaml:"sessionid"`
 }
 
+// Workday sets where entries reported with a span are placed: after the day start, around the lunch
+// and either after the latest entry or in the first gap that fits.
+type Workday struct {
+	Start    string `yaml:"start,omitempty"`
+	Lunch    string `yaml:"lunch,omitempty"`
+	Stacking string `yaml:"stacking,omitempty"`
+}
+
+// Template holds fields of a recurring report. Status is a pointer, as zero completeness is valid.
+type Template struct {
+	Project  string        `yaml:"project,omitempty"`
+	Activity string        `yaml:"activity,omitempty"`
+	Title    string        `yaml:"title,omitempty"`
+	Span     time.Duration `yaml:"span,omitempty"`
+	Status   *int          `yaml:"status,omitempty"`
+	Message  string        `yaml:"message,omitempty"`
+}
+
+// Meeting maps calendar events with the summary containing Match, ignoring the case, to the project and activity.
+// The first matching rule applies; Skip drops the events, e.g., focus time blocks.
+type Meeting struct {
+	Match    string `yaml:"match"`
+	Project  string `yaml:"project,omitempty"`
+	Activity string `yaml:"activity,omitempty"`
+	Skip     bool   `yaml:"skip,omitempty"`
+}
+
 func (c Cookies) IsZero() bool {
 	return len(c.CSRFToken) == 0 || len(c.SessionID) == 0
 }
@@ -3,8 +3,9 @@ package config_test
 import (
 	"context"
 	"io/ioutil"
-	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/kudrykv/go-vkpm/app/config"
 	. "github.com/smartystreets/goconvey/convey"
@@ -21,11 +22,10 @@ cookies:
 func TestNew(t *testing.T) {
 	Convey("New", t, func() {
 		ctx := context.Background()
+		dir := t.TempDir()
 
 		Convey("brand new", func() {
-			_ = os.Remove("./test_config.yml")
-
-			cfg, err := config.New(ctx, ".", "test_config.yml")
+			cfg, err := config.New(ctx, dir, "test_config.yml")
 			So(err, ShouldBeNil)
 
 			cfg.Domain = "domain"
@@ -36,13 +36,17 @@ func TestNew(t *testing.T) {
 			read, err := cfg.Read()
 			So(err, ShouldBeNil)
 			So(read, ShouldResemble, cfg)
+
+			bts, err := ioutil.ReadFile(filepath.Join(dir, "test_config.yml"))
+			So(err, ShouldBeNil)
+			So(string(bts), ShouldNotContainSubstring, "workday")
 		})
 
 		Convey("existing", func() {
-			err := ioutil.WriteFile("./test_existing_config.yml", []byte(testConfig), 0600)
+			err := ioutil.WriteFile(filepath.Join(dir, "test_existing_config.yml"), []byte(testConfig), 0600)
 			So(err, ShouldBeNil)
 
-			cfg, err := config.New(ctx, ".", "test_existing_config.yml")
+			cfg, err := config.New(ctx, dir, "test_existing_config.yml")
 			So(err, ShouldBeNil)
 
 			expected := cfg // need to copy, as has internal fields. it is easier this way
@@ -51,5 +55,31 @@ func TestNew(t *testing.T) {
 			expected.Cookies = config.Cookies{CSRFToken: "csrf", SessionID: "sessid"}
 			So(cfg, ShouldResemble, expected)
 		})
+
+		Convey("templates", func() {
+			err := ioutil.WriteFile(filepath.Join(dir, "test_templates_config.yml"), []byte(testTemplatesConfig), 0600)
+			So(err, ShouldBeNil)
+
+			cfg, err := config.New(ctx, dir, "test_templates_config.yml")
+			So(err, ShouldBeNil)
+
+			status := 0
+			So(cfg.Templates, ShouldResemble, map[string]config.Template{
+				"standup": {Project: "egg", Activity: "management", Span: 15 * time.Minute, Message: "standup"},
+				"spike":   {Project: "egg", Status: &status},
+			})
+		})
 	})
 }
+
+const testTemplatesConfig = `
+templates:
+  standup:
+    project: egg
+    activity: management
+    span: 15m
+    message: standup
+  spike:
+    project: egg
+    status: 0
+`
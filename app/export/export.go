@@ -0,0 +1,140 @@
+// Package export renders the reported entries of the month as a document to share, in Markdown or HTML.
+package export
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format, use md or html")
+
+	//go:embed report.md.tmpl
+	markdownReport string
+
+	//go:embed report.html.tmpl
+	htmlReport string
+
+	funcs = map[string]interface{}{
+		"hours": func(d time.Duration) string { return fmt.Sprintf("%.2f", d.Hours()) },
+		"cell":  markdownCell,
+	}
+
+	markdownTemplate = template.Must(template.New("md").Funcs(funcs).Parse(markdownReport))
+	htmlTemplate     = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(htmlReport))
+)
+
+// Total is the time reported for the project or the activity, and its share of the month.
+type Total struct {
+	Name     string
+	Duration time.Duration
+	Percent  int
+}
+
+// Day is the entries of the day in the order reported.
+type Day struct {
+	Date     types.Date
+	Duration time.Duration
+	Entries  types.ReportEntries
+}
+
+// Report is the summary of the month: totals by the project and the activity, and the entries by the day.
+type Report struct {
+	Month      types.Date
+	Duration   time.Duration
+	Projects   []Total
+	Activities []Total
+	Days       []Day
+}
+
+func NewReport(month types.Date, entries types.ReportEntries) Report {
+	report := Report{Month: month, Duration: entries.Duration()}
+
+	for _, ph := range entries.ProjectHours() {
+		report.Projects = append(report.Projects, report.total(ph.Project.Name, ph.Duration))
+	}
+
+	activities := map[string]time.Duration{}
+	for _, entry := range entries {
+		activities[entry.Activity] += entry.Span
+	}
+
+	for activity, duration := range activities {
+		report.Activities = append(report.Activities, report.total(activity, duration))
+	}
+
+	sort.Slice(report.Activities, func(i, j int) bool {
+		if report.Activities[i].Duration == report.Activities[j].Duration {
+			return report.Activities[i].Name < report.Activities[j].Name
+		}
+
+		return report.Activities[i].Duration > report.Activities[j].Duration
+	})
+
+	// the site may list the entries in any order, and the days are grouped from the adjacent ones
+	sorted := append(types.ReportEntries(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].ReportDate.Equal(sorted[j].ReportDate) {
+			return sorted[i].ReportDate.Before(sorted[j].ReportDate.Time)
+		}
+
+		return sorted[i].StartTime.Before(sorted[j].StartTime)
+	})
+
+	for _, day := range sorted.GroupByDays() {
+		report.Days = append(report.Days, Day{Date: day[0].ReportDate, Duration: day.Duration(), Entries: day})
+	}
+
+	return report
+}
+
+// Write renders the report in the format, md or html.
+func Write(w io.Writer, format string, report Report) error {
+	var err error
+
+	switch format {
+	case FormatMarkdown:
+		err = markdownTemplate.Execute(w, report)
+	case FormatHTML:
+		err = htmlTemplate.Execute(w, report)
+	default:
+		return fmt.Errorf("%s: %w", format, ErrUnknownFormat)
+	}
+
+	if err != nil {
+		return fmt.Errorf("execute %s: %w", format, err)
+	}
+
+	return nil
+}
+
+func (r Report) total(name string, duration time.Duration) Total {
+	total := Total{Name: name, Duration: duration}
+	if r.Duration > 0 {
+		total.Percent = int(math.Round(100 * duration.Hours() / r.Duration.Hours()))
+	}
+
+	return total
+}
+
+// markdownCell keeps the text in one cell of the table: pipes and tags are escaped, and lines are joined with breaks.
+func markdownCell(text string) string {
+	text = strings.NewReplacer("|", `\|`, "<", "&lt;").Replace(strings.TrimSpace(text))
+
+	return strings.Join(strings.Fields(strings.ReplaceAll(text, "\n", " <br> ")), " ")
+}
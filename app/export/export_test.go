@@ -0,0 +1,69 @@
+package export_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/export"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReport(t *testing.T) {
+	Convey("Report", t, func() {
+		day := func(d int) types.Date {
+			return types.Date{Time: time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC)}
+		}
+		entry := func(d int, project, activity string, span time.Duration, name string) types.ReportEntry {
+			return types.ReportEntry{
+				ReportDate: day(d), Project: types.Project{Name: project}, Activity: activity, Span: span, Name: name,
+			}
+		}
+
+		history := types.ReportEntries{
+			entry(6, "K4S", types.ActivityManagement, 2*time.Hour, "planning"),
+			entry(7, "Egg Inc.", types.ActivityDevelopment, 6*time.Hour, "login | signup"),
+			entry(6, "Egg Inc.", types.ActivityDevelopment, 4*time.Hour, "login"),
+		}
+		history[1].Description = "fixed <script>\nadded tests"
+		history[0].StartTime = time.Date(0, 1, 1, 13, 0, 0, 0, time.UTC)
+		history[2].StartTime = time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+
+		report := export.NewReport(day(1), history)
+
+		So(report.Duration, ShouldEqual, 12*time.Hour)
+		So(report.Projects, ShouldResemble, []export.Total{
+			{Name: "Egg Inc.", Duration: 10 * time.Hour, Percent: 83},
+			{Name: "K4S", Duration: 2 * time.Hour, Percent: 17},
+		})
+		So(report.Activities[0].Name, ShouldEqual, types.ActivityDevelopment)
+		So(report.Days, ShouldHaveLength, 2)
+		So(report.Days[0].Date, ShouldResemble, day(6))
+		So(report.Days[0].Duration, ShouldEqual, 6*time.Hour)
+		So(report.Days[0].Entries[0].Name, ShouldEqual, "login")
+
+		Convey("markdown", func() {
+			buf := bytes.Buffer{}
+			So(export.Write(&buf, export.FormatMarkdown, report), ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "# Activity report, May 2024")
+			So(buf.String(), ShouldContainSubstring, "| Egg Inc. | 10.00 | 83% |")
+			So(buf.String(), ShouldContainSubstring, `| login \| signup | fixed &lt;script> <br> added tests |`)
+		})
+
+		Convey("html", func() {
+			buf := bytes.Buffer{}
+			So(export.Write(&buf, export.FormatHTML, report), ShouldBeNil)
+			So(buf.String(), ShouldContainSubstring, "<html")
+			So(buf.String(), ShouldContainSubstring, "Egg Inc.")
+			So(buf.String(), ShouldContainSubstring, "fixed &lt;script&gt;")
+			So(buf.String(), ShouldNotContainSubstring, "<script>")
+		})
+
+		Convey("unknown format", func() {
+			err := export.Write(&bytes.Buffer{}, "pdf", report)
+			So(errors.Is(err, export.ErrUnknownFormat), ShouldBeTrue)
+		})
+	})
+}
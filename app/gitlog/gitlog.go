@@ -0,0 +1,107 @@
+// Package gitlog reads commits from local git repositories.
+package gitlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/th"
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+var (
+	ErrNoAuthor = errors.New("no author, set git user.email or pass it explicitly")
+)
+
+type Commit struct {
+	At      time.Time
+	Subject string
+}
+
+type Commits []Commit
+
+// Subjects returns unique subjects of the commits, oldest first.
+func (c Commits) Subjects() []string {
+	var (
+		subjects []string
+		seen     = map[string]bool{}
+	)
+
+	for i := len(c) - 1; i >= 0; i-- {
+		if subject := c[i].Subject; !seen[subject] {
+			seen[subject] = true
+			subjects = append(subjects, subject)
+		}
+	}
+
+	return subjects
+}
+
+// Author returns user.email from the git config of the repository.
+func Author(ctx context.Context, repo string) (string, error) {
+	out, err := git(ctx, repo, "config", "user.email")
+	if err != nil {
+		return "", fmt.Errorf("git config: %w", err)
+	}
+
+	author := strings.TrimSpace(out)
+	if len(author) == 0 {
+		return "", ErrNoAuthor
+	}
+
+	return author, nil
+}
+
+// Log returns non-merge commits from all branches by the author, authored on the day, newest first.
+func Log(ctx context.Context, repo, author string, day types.Date) (Commits, error) {
+	ctx, end := th.RegionTask(ctx, "git log")
+	defer end()
+
+	// commits authored on the day cannot be committed before it, while rebased ones are committed later
+	since := day.AddDate(0, 0, -1).Format("2006-01-02")
+
+	out, err := git(ctx, repo, "log", "--all", "--no-merges", "--author="+author, "--since="+since, "--format=%aI%x09%s")
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+
+	var commits Commits
+
+	for _, line := range strings.Split(out, "\n") {
+		date, subject, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+
+		at, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", date, err)
+		}
+
+		if at = at.In(day.Location()); !day.Equal(types.Date{Time: at}) {
+			continue
+		}
+
+		commits = append(commits, Commit{At: at, Subject: subject})
+	}
+
+	return commits, nil
+}
+
+func git(ctx context.Context, repo string, args ...string) (string, error) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repo}, args...)...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+
+	return stdout.String(), nil
+}
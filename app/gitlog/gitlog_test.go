@@ -0,0 +1,70 @@
+package gitlog_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/gitlog"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLog(t *testing.T) {
+	Convey("Log", t, func() {
+		ctx := context.Background()
+		repo := t.TempDir()
+		at := func(day, hour int) time.Time {
+			return time.Date(2021, time.May, day, hour, 0, 0, 0, time.Local)
+		}
+
+		git(t, repo, time.Now(), "init", "-q")
+		git(t, repo, time.Now(), "config", "user.email", "john@example.com")
+		git(t, repo, time.Now(), "config", "user.name", "John")
+
+		author, err := gitlog.Author(ctx, repo)
+		So(err, ShouldBeNil)
+		So(author, ShouldEqual, "john@example.com")
+
+		commit(t, repo, "john@example.com", at(12, 18), "day before")
+		commit(t, repo, "john@example.com", at(13, 10), "fix login")
+		commit(t, repo, "jane@example.com", at(13, 11), "not mine")
+		commit(t, repo, "john@example.com", at(13, 12), "add tests")
+		commit(t, repo, "john@example.com", at(13, 15), "fix login")
+		commit(t, repo, "john@example.com", at(14, 9), "day after")
+
+		commits, err := gitlog.Log(ctx, repo, author, types.Date{Time: at(13, 0)})
+		So(err, ShouldBeNil)
+		So(commits, ShouldHaveLength, 3)
+		So(commits[0].At.Equal(at(13, 15)), ShouldBeTrue)
+		So(commits.Subjects(), ShouldResemble, []string{"fix login", "add tests"})
+
+		commits, err = gitlog.Log(ctx, repo, author, types.Date{Time: at(20, 0)})
+		So(err, ShouldBeNil)
+		So(commits, ShouldBeEmpty)
+
+		_, err = gitlog.Log(ctx, t.TempDir(), author, types.Date{Time: at(13, 0)})
+		So(err, ShouldBeError)
+	})
+}
+
+func commit(t *testing.T, repo, author string, at time.Time, subject string) {
+	t.Helper()
+
+	git(t, repo, at, "-c", "user.email="+author, "commit", "-q", "--allow-empty", "-m", subject)
+}
+
+func git(t *testing.T, repo string, at time.Time, args ...string) {
+	t.Helper()
+
+	date := at.Format(time.RFC3339)
+
+	cmd := exec.Command("git", append([]string{"-C", repo}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date, "GIT_CONFIG_GLOBAL=/dev/null")
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
@@ -0,0 +1,428 @@
+// Package ics reads events from iCalendar files, as exported by calendar apps.
+package ics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+const (
+	layoutDate     = "20060102"
+	layoutDateTime = "20060102T150405"
+	statusCanceled = "CANCELLED"
+)
+
+var (
+	ErrUnsupportedRule = errors.New("unsupported recurrence rule")
+	ErrLongRule        = errors.New("recurrence too long to count the occurrences")
+	ErrBadEvent        = errors.New("bad event")
+)
+
+// Event is a single occurrence of a calendar event.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+type Events []Event
+
+// Calendar holds the events as written in the file, recurring ones not expanded.
+type Calendar struct {
+	events []event
+}
+
+type event struct {
+	Event
+
+	allDay       bool
+	status       string
+	rule         *rule
+	ruleErr      error
+	duration     time.Duration
+	exDates      []time.Time
+	recurrenceID time.Time
+	line         int
+}
+
+// Read reads the VEVENT components of the calendar. Other components, like alarms or time zones, are skipped;
+// TZID parameters are resolved with the IANA time zone database, falling back to the local time.
+func Read(r io.Reader) (Calendar, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("unfold: %w", err)
+	}
+
+	var (
+		cal     Calendar
+		current *event
+		nested  int
+	)
+
+	for _, line := range lines {
+		name, params, value := parseLine(line.text)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT" && current == nil:
+			current = &event{line: line.number}
+
+		case current == nil:
+
+		case name == "BEGIN":
+			nested++
+
+		case name == "END" && nested > 0:
+			nested--
+
+		case nested > 0:
+
+		case name == "END" && value == "VEVENT":
+			if err = current.finish(); err != nil {
+				return Calendar{}, fmt.Errorf("line %d: %w", current.line, err)
+			}
+
+			cal.events = append(cal.events, *current)
+			current = nil
+
+		default:
+			if err = current.set(name, params, value); err != nil {
+				return Calendar{}, fmt.Errorf("line %d: %s: %w", line.number, name, err)
+			}
+		}
+	}
+
+	return cal, nil
+}
+
+// On returns occurrences of timed events starting on the day, ordered by the start, in the location of the day.
+// All-day and canceled events are skipped, and occurrences moved to another time are taken from their overrides.
+func (c Calendar) On(day types.Date) (Events, error) {
+	var (
+		events    Events
+		overrides = map[string]bool{}
+	)
+
+	for _, e := range c.events {
+		if !e.recurrenceID.IsZero() {
+			overrides[e.UID+e.recurrenceID.UTC().Format(layoutDateTime)] = true
+		}
+	}
+
+	for _, e := range c.events {
+		if e.allDay || e.status == statusCanceled {
+			continue
+		}
+
+		if e.ruleErr != nil {
+			if e.Start.Before(day.AddDate(0, 0, 2).Time) {
+				return nil, fmt.Errorf("%s: %w", e.Summary, e.ruleErr)
+			}
+
+			continue
+		}
+
+		if e.rule == nil {
+			if day.Equal(types.Date{Time: e.Start.In(day.Location())}) {
+				events = append(events, e.Event)
+			}
+
+			continue
+		}
+
+		occurrence, ok, err := e.occurrence(day)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Summary, err)
+		}
+
+		if ok && !overrides[e.UID+occurrence.Start.UTC().Format(layoutDateTime)] {
+			events = append(events, occurrence)
+		}
+	}
+
+	for i := range events {
+		events[i].Start, events[i].End = events[i].Start.In(day.Location()), events[i].End.In(day.Location())
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	return events, nil
+}
+
+func (e *event) set(name string, params map[string]string, value string) error {
+	var err error
+
+	switch name {
+	case "UID":
+		e.UID = value
+	case "SUMMARY":
+		e.Summary = unescape(value)
+	case "STATUS":
+		e.status = strings.ToUpper(value)
+	case "DTSTART":
+		e.Start, e.allDay, err = parseTime(params, value)
+	case "DTEND":
+		e.End, _, err = parseTime(params, value)
+	case "DURATION":
+		e.duration, err = parseDuration(value)
+	case "RECURRENCE-ID":
+		e.recurrenceID, _, err = parseTime(params, value)
+	case "EXDATE":
+		for _, v := range strings.Split(value, ",") {
+			var at time.Time
+			if at, _, err = parseTime(params, v); err != nil {
+				break
+			}
+
+			e.exDates = append(e.exDates, at)
+		}
+	case "RRULE":
+		// a rule that cannot be expanded fails only the days it may apply to, not the whole calendar
+		e.rule, e.ruleErr = parseRule(value)
+	}
+
+	return err
+}
+
+func (e *event) finish() error {
+	if e.Start.IsZero() {
+		return fmt.Errorf("no start: %w", ErrBadEvent)
+	}
+
+	if e.End.IsZero() {
+		e.End = e.Start.Add(e.duration)
+	}
+
+	if e.End.Before(e.Start) {
+		return fmt.Errorf("ends before the start: %w", ErrBadEvent)
+	}
+
+	return nil
+}
+
+// occurrence finds the occurrence of the recurring event starting on the day. Occurrences are computed
+// in the time zone of the event, so the neighbour days are checked too, as they may fall on the day locally.
+func (e event) occurrence(day types.Date) (Event, bool, error) {
+	loc := e.Start.Location()
+	local := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+
+	for _, shift := range []int{0, -1, 1} {
+		candidate := local.AddDate(0, 0, shift)
+		start := time.Date(
+			candidate.Year(), candidate.Month(), candidate.Day(),
+			e.Start.Hour(), e.Start.Minute(), e.Start.Second(), 0, loc,
+		)
+
+		if !day.Equal(types.Date{Time: start.In(day.Location())}) {
+			continue
+		}
+
+		ok, err := e.occursAt(start)
+		if err != nil || !ok {
+			return Event{}, false, err
+		}
+
+		occurrence := e.Event
+		occurrence.Start, occurrence.End = start, start.Add(e.End.Sub(e.Start))
+
+		return occurrence, true, nil
+	}
+
+	return Event{}, false, nil
+}
+
+func (e event) occursAt(start time.Time) (bool, error) {
+	if start.Before(e.Start) || (!e.rule.until.IsZero() && start.After(e.rule.until)) {
+		return false, nil
+	}
+
+	ok, err := e.rule.matches(e.Start, start)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if e.rule.count > 0 {
+		count, err := e.rule.countUntil(e.Start, start, e.rule.count)
+		if err != nil {
+			return false, fmt.Errorf("count: %w", err)
+		}
+
+		if count > e.rule.count {
+			return false, nil
+		}
+	}
+
+	for _, exDate := range e.exDates {
+		if exDate.Equal(start) || (e.allDay && sameDate(exDate, start)) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+// unfold joins the lines continued with a leading space or tab.
+func unfold(r io.Reader) ([]line, error) {
+	var (
+		lines   []line
+		scanner = bufio.NewScanner(r)
+	)
+
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+
+			continue
+		}
+
+		if len(text) > 0 {
+			lines = append(lines, line{number: number, text: text})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseLine splits the content line into the name, the parameters and the value, e.g.
+//
+//	DTSTART;TZID=Europe/Kyiv:20210513T100000
+func parseLine(text string) (string, map[string]string, string) {
+	var (
+		quoted bool
+		colon  = -1
+	)
+
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		}
+
+		if r == ':' && !quoted {
+			colon = i
+
+			break
+		}
+	}
+
+	if colon < 0 {
+		return strings.ToUpper(text), nil, ""
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, text[colon+1:]
+}
+
+func parseTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(layoutDate) {
+		at, err := time.ParseInLocation(layoutDate, value, time.Local)
+		if err != nil {
+			return at, true, fmt.Errorf("parse date: %w", err)
+		}
+
+		return at, true, nil
+	}
+
+	loc := time.Local
+
+	if strings.HasSuffix(value, "Z") {
+		loc, value = time.UTC, strings.TrimSuffix(value, "Z")
+	} else if tzid := params["TZID"]; len(tzid) > 0 {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+
+	at, err := time.ParseInLocation(layoutDateTime, value, loc)
+	if err != nil {
+		return at, false, fmt.Errorf("parse date time: %w", err)
+	}
+
+	return at, false, nil
+}
+
+// parseDuration parses durations like PT1H30M or P1D; weeks and days are taken as 24 hours.
+func parseDuration(value string) (time.Duration, error) {
+	var (
+		span   time.Duration
+		number int
+		inTime bool
+		sign   time.Duration = 1
+	)
+
+	value = strings.ToUpper(value)
+	if strings.HasPrefix(value, "-") {
+		sign, value = -1, value[1:]
+	}
+
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "+"), "P")
+
+	for _, r := range value {
+		unit := time.Duration(0)
+
+		switch {
+		case r >= '0' && r <= '9':
+			number = number*10 + int(r-'0')
+
+			continue
+		case r == 'T':
+			inTime = true
+
+			continue
+		case r == 'W':
+			unit = 7 * 24 * time.Hour
+		case r == 'D':
+			unit = 24 * time.Hour
+		case r == 'H' && inTime:
+			unit = time.Hour
+		case r == 'M' && inTime:
+			unit = time.Minute
+		case r == 'S' && inTime:
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("duration %s: %w", value, ErrBadEvent)
+		}
+
+		span += time.Duration(number) * unit
+		number = 0
+	}
+
+	return sign * span, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+func sameDate(a, b time.Time) bool {
+	y1, m1, d1 := a.Date()
+	y2, m2, d2 := b.In(a.Location()).Date()
+
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
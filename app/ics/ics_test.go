@@ -0,0 +1,169 @@
+package ics_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/ics"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const calendar = `BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VTIMEZONE
+TZID:Europe/Kyiv
+BEGIN:STANDARD
+DTSTART:19701025T040000
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:standup
+SUMMARY:Daily standup
+DTSTART:20210510T090000
+DURATION:PT15M
+RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20210531T000000Z
+EXDATE:20210514T090000
+BEGIN:VALARM
+SUMMARY:not an event
+TRIGGER:-PT5M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+RECURRENCE-ID:20210513T090000
+SUMMARY:Daily standup
+DTSTART:20210513T093000
+DTEND:20210513T094500
+END:VEVENT
+BEGIN:VEVENT
+UID:planning
+SUMMARY:Sprint planning\, egg
+DTSTART:20210513T110000
+DTEND:20210513T
+ 123000
+END:VEVENT
+BEGIN:VEVENT
+UID:retro
+SUMMARY:Retro
+DTSTART:20210429T140000
+DTEND:20210429T150000
+RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TH;COUNT=2
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled
+SUMMARY:Cancelled
+STATUS:CANCELLED
+DTSTART:20210513T150000
+DTEND:20210513T160000
+END:VEVENT
+BEGIN:VEVENT
+UID:holiday
+SUMMARY:Day off
+DTSTART;VALUE=DATE:20210513
+DTEND;VALUE=DATE:20210514
+END:VEVENT
+BEGIN:VEVENT
+UID:monthly
+SUMMARY:Demo
+DTSTART:20210408T160000
+DTEND:20210408T170000
+RRULE:FREQ=MONTHLY;BYDAY=2TH
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestCalendar_On(t *testing.T) {
+	Convey("On", t, func() {
+		cal, err := ics.Read(strings.NewReader(strings.ReplaceAll(calendar, "\n", "\r\n")))
+		So(err, ShouldBeNil)
+
+		at := func(day, hour, minute int) time.Time {
+			return time.Date(2021, time.May, day, hour, minute, 0, 0, time.Local)
+		}
+		may := func(day int) types.Date { return types.Date{Time: at(day, 0, 0)} }
+		summaries := func(events ics.Events) []string {
+			list := make([]string, 0, len(events))
+			for _, event := range events {
+				list = append(list, event.Summary+" "+event.Start.Format("15:04")+"-"+event.End.Format("15:04"))
+			}
+
+			return list
+		}
+
+		Convey("expands recurring events and applies overrides", func() {
+			events, err := cal.On(may(13))
+			So(err, ShouldBeNil)
+			So(summaries(events), ShouldResemble, []string{
+				"Daily standup 09:30-09:45",
+				"Sprint planning, egg 11:00-12:30",
+				"Retro 14:00-15:00",
+				"Demo 16:00-17:00",
+			})
+		})
+
+		Convey("skips excluded occurrences", func() {
+			events, err := cal.On(may(14))
+			So(err, ShouldBeNil)
+			So(events, ShouldBeEmpty)
+		})
+
+		Convey("plain occurrence", func() {
+			events, err := cal.On(may(12))
+			So(err, ShouldBeNil)
+			So(summaries(events), ShouldResemble, []string{"Daily standup 09:00-09:15"})
+		})
+
+		Convey("stops at the count and until", func() {
+			events, err := cal.On(may(27))
+			So(err, ShouldBeNil)
+			So(summaries(events), ShouldResemble, []string{"Daily standup 09:00-09:15"})
+
+			events, err = cal.On(types.Date{Time: time.Date(2021, time.June, 1, 0, 0, 0, 0, time.Local)})
+			So(err, ShouldBeNil)
+			So(events, ShouldBeEmpty)
+		})
+
+		Convey("counts by the periods of the rule", func() {
+			cal, err := ics.Read(strings.NewReader("" +
+				"BEGIN:VEVENT\nSUMMARY:Monthly\nDTSTART:19910513T100000\nDTEND:19910513T110000\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=2TH;COUNT=400\nEND:VEVENT\n" +
+				"BEGIN:VEVENT\nSUMMARY:Yearly\nDTSTART:20190513T120000\nDTEND:20190513T130000\n" +
+				"RRULE:FREQ=YEARLY;COUNT=2\nEND:VEVENT\n"))
+			So(err, ShouldBeNil)
+
+			events, err := cal.On(may(13))
+			So(err, ShouldBeNil)
+			So(summaries(events), ShouldResemble, []string{"Monthly 10:00-11:00"})
+		})
+
+		Convey("too long a count", func() {
+			cal, err := ics.Read(strings.NewReader("" +
+				"BEGIN:VEVENT\nSUMMARY:Ancient\nDTSTART:17000510T090000\n" +
+				"RRULE:FREQ=DAILY;COUNT=1000000\nEND:VEVENT\n"))
+			So(err, ShouldBeNil)
+
+			_, err = cal.On(may(13))
+			So(errors.Is(err, ics.ErrLongRule), ShouldBeTrue)
+		})
+
+		Convey("unsupported rule", func() {
+			cal, err := ics.Read(strings.NewReader("" +
+				"BEGIN:VEVENT\nSUMMARY:Odd\nDTSTART:20210510T090000\nRRULE:FREQ=WEEKLY;BYSETPOS=1\nEND:VEVENT\n"))
+			So(err, ShouldBeNil)
+
+			_, err = cal.On(may(5))
+			So(err, ShouldBeNil)
+
+			_, err = cal.On(may(13))
+			So(errors.Is(err, ics.ErrUnsupportedRule), ShouldBeTrue)
+		})
+
+		Convey("bad event", func() {
+			_, err := ics.Read(strings.NewReader("BEGIN:VEVENT\nSUMMARY:No start\nEND:VEVENT\n"))
+			So(errors.Is(err, ics.ErrBadEvent), ShouldBeTrue)
+		})
+	})
+}
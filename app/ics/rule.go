@@ -0,0 +1,270 @@
+package ics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rule is the subset of RRULE calendar apps use for meetings: daily, weekly on the days,
+// monthly on the day or on the n-th weekday, and yearly, with the interval, count and until.
+type rule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []weekday
+	byMonthDay []int
+}
+
+// maxPeriods caps the periods of the rule walked to count the occurrences, some 270 years of a daily one.
+const maxPeriods = 100000
+
+// weekday is the day of BYDAY, with the optional position in the month, e.g., 2TU or -1FR.
+type weekday struct {
+	day time.Weekday
+	nth int
+}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+func parseRule(value string) (*rule, error) {
+	r := &rule{interval: 1}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+
+		var err error
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.freq = strings.ToUpper(val)
+		case "INTERVAL":
+			r.interval, err = strconv.Atoi(val)
+		case "COUNT":
+			r.count, err = strconv.Atoi(val)
+		case "UNTIL":
+			r.until, _, err = parseTime(nil, val)
+		case "BYDAY":
+			r.byDay, err = parseWeekdays(val)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseInts(val)
+		case "WKST":
+		default:
+			err = fmt.Errorf("%s: %w", key, ErrUnsupportedRule)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", value, err)
+		}
+	}
+
+	switch r.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	default:
+		return nil, fmt.Errorf("%s: %w", value, ErrUnsupportedRule)
+	}
+
+	if r.interval < 1 {
+		r.interval = 1
+	}
+
+	return r, nil
+}
+
+// matches tells if the rule of the event started at first has an occurrence at the time.
+func (r rule) matches(first, at time.Time) (bool, error) {
+	switch r.freq {
+	case "DAILY":
+		return days(first, at)%r.interval == 0 && r.onWeekday(at), nil
+
+	case "WEEKLY":
+		// weeks start on Monday, as WKST defaults to MO
+		monday := func(t time.Time) time.Time { return t.AddDate(0, 0, -(int(t.Weekday())+6)%7) }
+		if weeks := days(monday(first), monday(at)) / 7; weeks%r.interval != 0 {
+			return false, nil
+		}
+
+		if len(r.byDay) == 0 {
+			return at.Weekday() == first.Weekday(), nil
+		}
+
+		return r.onWeekday(at), nil
+
+	case "MONTHLY":
+		months := (at.Year()-first.Year())*12 + int(at.Month()-first.Month())
+		if months%r.interval != 0 {
+			return false, nil
+		}
+
+		return r.inMonth(first, at), nil
+
+	case "YEARLY":
+		if (at.Year()-first.Year())%r.interval != 0 || at.Month() != first.Month() {
+			return false, nil
+		}
+
+		return r.inMonth(first, at), nil
+	}
+
+	return false, fmt.Errorf("%s: %w", r.freq, ErrUnsupportedRule)
+}
+
+// countUntil counts the occurrences of the rule of the event started at first, up to the time inclusive.
+// It steps by the periods of the rule, and stops once the count goes over the limit.
+func (r rule) countUntil(first, at time.Time, limit int) (int, error) {
+	count := 0
+
+	for n := 0; n < maxPeriods; n++ {
+		start, length := r.period(first, n)
+
+		for i := 0; i < length; i++ {
+			day := start.AddDate(0, 0, i)
+
+			switch {
+			case day.Before(first):
+				continue
+			case day.After(at):
+				return count, nil
+			}
+
+			ok, err := r.matches(first, day)
+			if err != nil {
+				return count, err
+			}
+
+			if count += boolToInt(ok); count > limit {
+				return count, nil
+			}
+		}
+	}
+
+	return count, fmt.Errorf("over %d periods: %w", maxPeriods, ErrLongRule)
+}
+
+// period is the start of the n-th period of the rule from the first occurrence, at its clock, and the days in it:
+// the day, the week from Monday, the month or the month of the first occurrence in the year.
+func (r rule) period(first time.Time, n int) (time.Time, int) {
+	month := func(year int, month time.Month) (time.Time, int) {
+		start := time.Date(year, month, 1, first.Hour(), first.Minute(), first.Second(), 0, first.Location())
+
+		return start, start.AddDate(0, 1, -1).Day()
+	}
+
+	switch r.freq {
+	case "WEEKLY":
+		return first.AddDate(0, 0, -(int(first.Weekday())+6)%7+7*r.interval*n), 7
+	case "MONTHLY":
+		return month(first.Year(), first.Month()+time.Month(r.interval*n))
+	case "YEARLY":
+		return month(first.Year()+r.interval*n, first.Month())
+	}
+
+	return first.AddDate(0, 0, r.interval*n), 1
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func (r rule) onWeekday(at time.Time) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+
+	for _, wd := range r.byDay {
+		if wd.day == at.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inMonth tells if the day of the month matches BYMONTHDAY or BYDAY with the position, or the day of the first one.
+func (r rule) inMonth(first, at time.Time) bool {
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		return at.Day() == first.Day()
+	}
+
+	last := time.Date(at.Year(), at.Month()+1, 0, 0, 0, 0, 0, at.Location()).Day()
+
+	for _, day := range r.byMonthDay {
+		if day == at.Day() || (day < 0 && last+day+1 == at.Day()) {
+			return true
+		}
+	}
+
+	for _, wd := range r.byDay {
+		if wd.day != at.Weekday() {
+			continue
+		}
+
+		if nth := (at.Day()-1)/7 + 1; wd.nth == 0 || wd.nth == nth || wd.nth == -((last-at.Day())/7+1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseWeekdays(value string) ([]weekday, error) {
+	var list []weekday
+
+	for _, v := range strings.Split(strings.ToUpper(value), ",") {
+		if len(v) < 2 {
+			return nil, fmt.Errorf("%s: %w", v, ErrUnsupportedRule)
+		}
+
+		day, ok := weekdays[v[len(v)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", v, ErrUnsupportedRule)
+		}
+
+		wd := weekday{day: day}
+
+		if prefix := v[:len(v)-2]; len(prefix) > 0 {
+			nth, err := strconv.Atoi(prefix)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", v, ErrUnsupportedRule)
+			}
+
+			wd.nth = nth
+		}
+
+		list = append(list, wd)
+	}
+
+	return list, nil
+}
+
+func parseInts(value string) ([]int, error) {
+	var list []int
+
+	for _, v := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("atoi: %w", err)
+		}
+
+		list = append(list, n)
+	}
+
+	return list, nil
+}
+
+// days counts calendar days between the dates, ignoring the clock and daylight saving shifts.
+func days(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(b.Sub(a).Hours() / 24)
+}
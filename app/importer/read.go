@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown file format, use .yml, .yaml or .csv")
+	ErrUnknownColumn = errors.New("unknown column")
+)
+
+// ReadFile reads records from YAML or CSV file, depending on its extension.
+func ReadFile(path string) (Records, error) {
+	sock, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer func() { _ = sock.Close() }()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return ReadYAML(sock)
+	case ".csv":
+		return ReadCSV(sock)
+	}
+
+	return nil, fmt.Errorf("%s: %w", path, ErrUnknownFormat)
+}
+
+// ReadYAML reads a sequence of mappings with the keys named after the record fields.
+func ReadYAML(r io.Reader) (Records, error) {
+	var nodes []yaml.Node
+
+	if err := yaml.NewDecoder(r).Decode(&nodes); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	records := make(Records, 0, len(nodes))
+
+	for _, node := range nodes {
+		var record Record
+		if err := node.Decode(&record); err != nil {
+			return nil, fmt.Errorf("line %d: decode: %w", node.Line, err)
+		}
+
+		record.Line = node.Line
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ReadCSV reads records from CSV with the header naming the columns, e.g.:
+//
+//	date,project,span,message
+//	2021-05-13,egg,2h,did stuff
+func ReadCSV(r io.Reader) (Records, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	var records Records
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line}
+
+		for i, column := range header {
+			if i >= len(row) {
+				break
+			}
+
+			field := record.field(strings.ToLower(strings.TrimSpace(column)))
+			if field == nil {
+				return nil, fmt.Errorf("line %d: %s: %w", line, column, ErrUnknownColumn)
+			}
+
+			*field = strings.TrimSpace(row[i])
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r *Record) field(column string) *string {
+	fields := map[string]*string{
+		"date":     &r.Date,
+		"project":  &r.Project,
+		"activity": &r.Activity,
+		"span":     &r.Span,
+		"from":     &r.From,
+		"to":       &r.To,
+		"status":   &r.Status,
+		"title":    &r.Title,
+		"message":  &r.Message,
+	}
+
+	return fields[column]
+}
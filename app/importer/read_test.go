@@ -0,0 +1,89 @@
+package importer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const recordsYAML = `
+- date: 2021-05-13
+  project: egg
+  span: 2h
+  message: dealing with email
+
+- date: 2021-05-14
+  project: k4s
+  activity: man
+  from: "10:00"
+  to: "11:30"
+  status: 50
+  title: sync
+  message: weekly sync
+`
+
+const recordsCSV = `date, project, span, from, to, message
+2021-05-13,egg,2h,,,dealing with email
+2021-05-14,k4s,,10:00,11:30,"weekly sync, notes"
+`
+
+func TestRead(t *testing.T) {
+	Convey("Read", t, func() {
+		Convey("yaml", func() {
+			records, err := importer.ReadYAML(strings.NewReader(recordsYAML))
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 2)
+			So(records[0].Line, ShouldEqual, 2)
+			So(records[1].Line, ShouldEqual, 7)
+			So(records[1].Status, ShouldEqual, "50")
+
+			entry, err := records[1].Entry("")
+			So(err, ShouldBeNil)
+			So(entry.Activity, ShouldEqual, types.ActivityManagement)
+			So(entry.EndTime.Sub(entry.StartTime), ShouldEqual, 90*time.Minute)
+			So(entry.Name, ShouldEqual, "sync")
+		})
+
+		Convey("csv", func() {
+			records, err := importer.ReadCSV(strings.NewReader(recordsCSV))
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 2)
+			So(records[1].Line, ShouldEqual, 3)
+			So(records[1].Message, ShouldEqual, "weekly sync, notes")
+
+			entry, err := records[0].Entry("")
+			So(err, ShouldBeNil)
+			So(entry.Span, ShouldEqual, 2*time.Hour)
+			So(entry.Status, ShouldEqual, 100)
+			So(entry.Activity, ShouldEqual, types.ActivityDevelopment)
+		})
+
+		Convey("csv with unknown column", func() {
+			_, err := importer.ReadCSV(strings.NewReader("date,hours\n2021-05-13,2\n"))
+			So(errors.Is(err, importer.ErrUnknownColumn), ShouldBeTrue)
+		})
+
+		Convey("invalid records", func() {
+			record := importer.Record{Date: "2021-05-13", Message: "stuff", Span: "1h"}
+
+			_, err := record.Entry("")
+			So(err, ShouldEqual, importer.ErrNoProject)
+
+			_, err = record.Entry("egg")
+			So(err, ShouldBeNil)
+
+			record.From, record.To = "10:00", "11:00"
+			_, err = record.Entry("egg")
+			So(errors.Is(err, types.ErrRangeAndSpan), ShouldBeTrue)
+
+			record.Span, record.From, record.To = "1h05m", "", ""
+			_, err = record.Entry("egg")
+			So(errors.Is(err, types.ErrTimeNotRounded), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,107 @@
+// Package importer reads report entries from files.
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+var (
+	ErrNoDate    = errors.New("no date")
+	ErrNoProject = errors.New("no project")
+)
+
+type Records []Record
+
+// Record is a report entry as written in the file, not validated yet.
+type Record struct {
+	Line     int    `yaml:"-"`
+	Date     string `yaml:"date"`
+	Project  string `yaml:"project,omitempty"`
+	Activity string `yaml:"activity,omitempty"`
+	Span     string `yaml:"span,omitempty"`
+	From     string `yaml:"from,omitempty"`
+	To       string `yaml:"to,omitempty"`
+	Status   string `yaml:"status,omitempty"`
+	Title    string `yaml:"title,omitempty"`
+	Message  string `yaml:"message"`
+}
+
+// Entry validates the record and turns it into the entry to report. Project falls back to defProject,
+// activity to development and status to 100.
+func (r Record) Entry(defProject string) (types.ReportEntry, error) {
+	var (
+		entry = types.ReportEntry{
+			Project:     types.Project{Name: r.Project},
+			Name:        r.Title,
+			Description: r.Message,
+			Status:      100,
+		}
+		err error
+	)
+
+	if len(r.Date) == 0 {
+		return entry, ErrNoDate
+	}
+
+	if entry.ReportDate, err = types.ParseDay(r.Date, types.Today()); err != nil {
+		return entry, fmt.Errorf("date: %w", err)
+	}
+
+	if len(entry.Project.Name) == 0 {
+		if entry.Project.Name = defProject; len(entry.Project.Name) == 0 {
+			return entry, ErrNoProject
+		}
+	}
+
+	if len(entry.Description) == 0 {
+		return entry, types.ErrNoMessage
+	}
+
+	activity := r.Activity
+	if len(activity) == 0 {
+		activity = types.ActivityDevelopment
+	}
+
+	if entry, err = entry.SetActivity(activity); err != nil {
+		return entry, fmt.Errorf("set activity: %w", err)
+	}
+
+	if len(r.Status) > 0 {
+		if entry.Status, err = strconv.Atoi(r.Status); err != nil {
+			return entry, fmt.Errorf("status: %w", err)
+		}
+	}
+
+	if err = entry.TestStatus(); err != nil {
+		return entry, fmt.Errorf("test status %d: %w", entry.Status, err)
+	}
+
+	if len(r.Span) > 0 {
+		if entry.Span, err = time.ParseDuration(r.Span); err != nil {
+			return entry, fmt.Errorf("span: %w", err)
+		}
+	}
+
+	if len(r.From) > 0 {
+		if entry.StartTime, err = time.Parse("15:04", r.From); err != nil {
+			return entry, fmt.Errorf("from: %w", err)
+		}
+	}
+
+	if len(r.To) > 0 {
+		if entry.EndTime, err = time.Parse("15:04", r.To); err != nil {
+			return entry, fmt.Errorf("to: %w", err)
+		}
+	}
+
+	if err = entry.TestTime(); err != nil {
+		return entry, fmt.Errorf("test time: %w", err)
+	}
+
+	return entry, nil
+}
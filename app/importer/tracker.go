@@ -0,0 +1,403 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+const (
+	FormatToggl       = "toggl"
+	FormatClockify    = "clockify"
+	FormatTimewarrior = "timewarrior"
+)
+
+var (
+	ErrUnknownTracker = errors.New("unknown format, use toggl, clockify or timewarrior")
+	ErrNoColumn       = errors.New("no column")
+	ErrTooShort       = errors.New("shorter than 10 minutes once rounded")
+	ErrNextDay        = errors.New("ends on the next day")
+
+	dateLayouts = []string{"2006-01-02", "01/02/2006", "02.01.2006"}
+	timeLayouts = []string{"15:04:05", "15:04", "03:04:05 PM", "3:04:05 PM", "03:04 PM", "3:04 PM"}
+)
+
+// Track is a time entry exported from a time tracker. Line is the line in CSV, or the position in JSON.
+type Track struct {
+	Line        int
+	Project     string
+	Description string
+	Tags        []string
+	Start       time.Time
+	End         time.Time
+}
+
+type Tracks []Track
+
+// Skip is a track that cannot be reported, with the reason: ErrTooShort or ErrNextDay.
+type Skip struct {
+	Track  Track
+	Reason error
+}
+
+// ReadTrackerFile reads the export of the time tracker: CSV or JSON for Toggl and Clockify, by the extension,
+// and JSON of timew export for Timewarrior.
+func ReadTrackerFile(format, path string) (Tracks, error) {
+	sock, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer func() { _ = sock.Close() }()
+
+	isJSON := strings.ToLower(filepath.Ext(path)) == ".json"
+
+	switch format = strings.ToLower(format); format {
+	case FormatToggl, FormatClockify:
+		if isJSON && format == FormatToggl {
+			return readTogglJSON(sock)
+		}
+
+		if isJSON {
+			return readClockifyJSON(sock)
+		}
+
+		return readTrackerCSV(sock)
+
+	case FormatTimewarrior:
+		return readTimewarrior(sock)
+	}
+
+	return nil, fmt.Errorf("%s: %w", format, ErrUnknownTracker)
+}
+
+// Records turns tracks into records rounded to 10 minutes. The project is the one of the track, or the first tag
+// known as the alias, or the first tag. The message is the description, or the rest of the tags.
+// Tracks that cannot be reported, like the ones of a few minutes or the ones over midnight, are returned apart.
+func (t Tracks) Records(aliases types.ProjectAliases) (Records, []Skip, error) {
+	var (
+		records Records
+		skipped []Skip
+	)
+
+	for _, track := range t {
+		record := Record{Line: track.Line, Project: track.Project, Message: track.Description}
+		tags := track.Tags
+
+		if len(record.Project) == 0 && len(tags) > 0 {
+			idx := 0
+
+			for i, tag := range tags {
+				if aliases.Resolve(tag) != tag {
+					idx = i
+
+					break
+				}
+			}
+
+			record.Project = tags[idx]
+			tags = append(append([]string{}, tags[:idx]...), tags[idx+1:]...)
+		}
+
+		if len(record.Message) == 0 {
+			record.Message = strings.Join(tags, ", ")
+		}
+
+		record, err := record.WithTimes(track.Start, track.End)
+
+		switch {
+		case errors.Is(err, ErrTooShort):
+			skipped = append(skipped, Skip{Track: track, Reason: ErrTooShort})
+
+			continue
+		case errors.Is(err, ErrNextDay):
+			skipped = append(skipped, Skip{Track: track, Reason: ErrNextDay})
+
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", track.Line, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, skipped, nil
+}
+
+// WithTimes sets the date and the range from the times rounded to 10 minutes, in the local time.
+func (r Record) WithTimes(start, end time.Time) (Record, error) {
+	start, end = start.Local(), end.Local()
+	from, to := roundClock(start), roundClock(end)
+
+	year, month, day := start.Date()
+	if midnight := time.Date(year, month, day+1, 0, 0, 0, 0, time.Local); !end.Before(midnight) {
+		to += 24 * time.Hour
+	}
+
+	if to >= 24*time.Hour {
+		return r, fmt.Errorf("%s: %w", start.Format("2006-01-02 15:04"), ErrNextDay)
+	}
+
+	if to <= from {
+		return r, fmt.Errorf("%s-%s: %w", start.Format("15:04"), end.Format("15:04"), ErrTooShort)
+	}
+
+	r.Date = start.Format("2006-01-02")
+	r.From = formatClock(from)
+	r.To = formatClock(to)
+
+	return r, nil
+}
+
+// readTrackerCSV reads detailed reports of Toggl and Clockify, having the same columns named in the header.
+func readTrackerCSV(r io.Reader) (Tracks, error) {
+	reader := csv.NewReader(r)
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))] = i
+	}
+
+	for _, column := range []string{"project", "description", "start date", "start time", "end date", "end time"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%s: %w", column, ErrNoColumn)
+		}
+	}
+
+	var tracks Tracks
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			if idx, ok := columns[column]; ok && idx < len(row) {
+				return strings.TrimSpace(row[idx])
+			}
+
+			return ""
+		}
+
+		track := Track{
+			Line: line, Project: get("project"), Description: get("description"), Tags: splitTags(get("tags")),
+		}
+
+		if track.Start, err = parseDateTime(get("start date"), get("start time")); err != nil {
+			return nil, fmt.Errorf("line %d: start: %w", line, err)
+		}
+
+		if track.End, err = parseDateTime(get("end date"), get("end time")); err != nil {
+			return nil, fmt.Errorf("line %d: end: %w", line, err)
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// readTogglJSON reads time entries of the detailed report, either as the list or under data.
+func readTogglJSON(r io.Reader) (Tracks, error) {
+	type entry struct {
+		Description string    `json:"description"`
+		Project     string    `json:"project"`
+		ProjectName string    `json:"project_name"`
+		Tags        []string  `json:"tags"`
+		Start       time.Time `json:"start"`
+		End         time.Time `json:"end"`
+		Stop        time.Time `json:"stop"`
+	}
+
+	var entries []entry
+	if err := decodeList(r, "data", &entries); err != nil {
+		return nil, fmt.Errorf("decode list: %w", err)
+	}
+
+	tracks := make(Tracks, 0, len(entries))
+
+	for i, e := range entries {
+		track := Track{
+			Line: i + 1, Project: e.Project, Description: e.Description, Tags: e.Tags, Start: e.Start, End: e.End,
+		}
+		if len(track.Project) == 0 {
+			track.Project = e.ProjectName
+		}
+
+		if track.End.IsZero() {
+			track.End = e.Stop
+		}
+
+		// the running entry has no end yet
+		if !track.End.IsZero() {
+			tracks = append(tracks, track)
+		}
+	}
+
+	return tracks, nil
+}
+
+// readClockifyJSON reads time entries of the detailed report, either as the list or under timeentries.
+func readClockifyJSON(r io.Reader) (Tracks, error) {
+	type name struct {
+		Name string `json:"name"`
+	}
+
+	type entry struct {
+		Description  string `json:"description"`
+		ProjectName  string `json:"projectName"`
+		Project      name   `json:"project"`
+		Tags         []name `json:"tags"`
+		TimeInterval struct {
+			Start time.Time  `json:"start"`
+			End   *time.Time `json:"end"`
+		} `json:"timeInterval"`
+	}
+
+	var entries []entry
+	if err := decodeList(r, "timeentries", &entries); err != nil {
+		return nil, fmt.Errorf("decode list: %w", err)
+	}
+
+	tracks := make(Tracks, 0, len(entries))
+
+	for i, e := range entries {
+		if e.TimeInterval.End == nil {
+			continue
+		}
+
+		track := Track{
+			Line: i + 1, Project: e.ProjectName, Description: e.Description,
+			Start: e.TimeInterval.Start, End: *e.TimeInterval.End,
+		}
+
+		if len(track.Project) == 0 {
+			track.Project = e.Project.Name
+		}
+
+		for _, tag := range e.Tags {
+			track.Tags = append(track.Tags, tag.Name)
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// readTimewarrior reads the output of timew export. Projects are tags there.
+func readTimewarrior(r io.Reader) (Tracks, error) {
+	var entries []struct {
+		Start      string   `json:"start"`
+		End        string   `json:"end"`
+		Tags       []string `json:"tags"`
+		Annotation string   `json:"annotation"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	tracks := make(Tracks, 0, len(entries))
+
+	for i, e := range entries {
+		if len(e.End) == 0 {
+			continue
+		}
+
+		track := Track{Line: i + 1, Description: e.Annotation, Tags: e.Tags}
+
+		var err error
+		if track.Start, err = time.Parse("20060102T150405Z", e.Start); err != nil {
+			return nil, fmt.Errorf("entry %d: start: %w", i+1, err)
+		}
+
+		if track.End, err = time.Parse("20060102T150405Z", e.End); err != nil {
+			return nil, fmt.Errorf("entry %d: end: %w", i+1, err)
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// decodeList decodes the JSON list, either top-level or under the key of the object.
+func decodeList(r io.Reader, key string, list interface{}) error {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	if trimmed := strings.TrimSpace(string(raw)); strings.HasPrefix(trimmed, "{") {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &object); err != nil {
+			return fmt.Errorf("unmarshal object: %w", err)
+		}
+
+		raw = object[key]
+	}
+
+	if err := json.Unmarshal(raw, list); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func parseDateTime(date, clock string) (time.Time, error) {
+	for _, dateLayout := range dateLayouts {
+		for _, timeLayout := range timeLayouts {
+			if at, err := time.ParseInLocation(dateLayout+" "+timeLayout, date+" "+clock, time.Local); err == nil {
+				return at, nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%s %s: %w", date, clock, types.ErrBadDate)
+}
+
+func splitTags(value string) []string {
+	var tags []string
+
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// roundClock returns the time since midnight rounded to 10 minutes.
+func roundClock(t time.Time) time.Duration {
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	return (clock + 5*time.Minute).Truncate(10 * time.Minute)
+}
+
+func formatClock(clock time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(clock.Hours()), int(clock.Minutes())%60)
+}
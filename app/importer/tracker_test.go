@@ -0,0 +1,134 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const togglCSV = `User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags
+John,john@example.com,,Egg (client),,fixing login,No,2021-05-13,09:02:11,2021-05-13,10:28:40,01:26:29,
+John,john@example.com,,Egg (client),,quick call,No,2021-05-13,10:28:40,2021-05-13,10:31:02,00:02:22,
+`
+
+const clockifyJSON = `{"timeentries": [
+  {"description": "planning", "projectName": "K4S", "tags": [{"name": "meeting"}],
+   "timeInterval": {"start": "2021-05-13T11:00:00Z", "end": "2021-05-13T11:45:00Z"}},
+  {"description": "running", "projectName": "K4S", "timeInterval": {"start": "2021-05-13T12:00:00Z", "end": null}}
+]}`
+
+const timewarriorJSON = `[
+  {"id": 2, "start": "20210513T130000Z", "end": "20210513T150000Z", "tags": ["review", "egg"]},
+  {"id": 1, "start": "20210513T150000Z", "end": "20210513T160000Z", "tags": ["egg"], "annotation": "sync"}
+]`
+
+func TestReadTrackerFile(t *testing.T) {
+	Convey("ReadTrackerFile", t, func() {
+		dir := t.TempDir()
+		write := func(name, content string) string {
+			path := filepath.Join(dir, name)
+			So(os.WriteFile(path, []byte(content), 0600), ShouldBeNil)
+
+			return path
+		}
+		local := func(hour, minute int) time.Time {
+			return time.Date(2021, time.May, 13, hour, minute, 0, 0, time.UTC).Local()
+		}
+
+		Convey("toggl csv", func() {
+			tracks, err := importer.ReadTrackerFile("toggl", write("toggl.csv", togglCSV))
+			So(err, ShouldBeNil)
+			So(tracks, ShouldHaveLength, 2)
+			So(tracks[0].Project, ShouldEqual, "Egg (client)")
+			So(tracks[0].Line, ShouldEqual, 2)
+
+			records, skipped, err := tracks.Records(nil)
+			So(err, ShouldBeNil)
+			So(skipped, ShouldHaveLength, 1)
+			So(skipped[0].Reason, ShouldEqual, importer.ErrTooShort)
+			So(records, ShouldResemble, importer.Records{{
+				Line: 2, Date: "2021-05-13", Project: "Egg (client)", From: "09:00", To: "10:30", Message: "fixing login",
+			}})
+		})
+
+		Convey("clockify json", func() {
+			tracks, err := importer.ReadTrackerFile("Clockify", write("clockify.json", clockifyJSON))
+			So(err, ShouldBeNil)
+			So(tracks, ShouldHaveLength, 1)
+			So(tracks[0].Tags, ShouldResemble, []string{"meeting"})
+			So(tracks[0].Start.Equal(local(11, 0)), ShouldBeTrue)
+		})
+
+		Convey("timewarrior", func() {
+			tracks, err := importer.ReadTrackerFile("timewarrior", write("timew.json", timewarriorJSON))
+			So(err, ShouldBeNil)
+			So(tracks, ShouldHaveLength, 2)
+
+			So(tracks[0].Start.Equal(local(13, 0)), ShouldBeTrue)
+
+			// on the local clock, as the track may go over midnight in some zones
+			track := tracks[0]
+			track.Start = time.Date(2021, time.May, 13, 13, 0, 0, 0, time.Local)
+			track.End = track.Start.Add(2 * time.Hour)
+
+			records, _, err := importer.Tracks{track}.Records(types.ProjectAliases{"egg": "Egg Inc."})
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Project, ShouldEqual, "egg")
+			So(records[0].Message, ShouldEqual, "review")
+			So(records[0].From, ShouldEqual, "13:00")
+		})
+
+		Convey("over midnight", func() {
+			late := importer.Track{
+				Line:    2,
+				Project: "egg",
+				Start:   time.Date(2021, time.May, 12, 22, 0, 0, 0, time.Local),
+				End:     time.Date(2021, time.May, 13, 1, 0, 0, 0, time.Local),
+			}
+			tracks := importer.Tracks{late, {
+				Line:    3,
+				Project: "egg",
+				Start:   time.Date(2021, time.May, 13, 9, 0, 0, 0, time.Local),
+				End:     time.Date(2021, time.May, 13, 10, 0, 0, 0, time.Local),
+			}}
+
+			records, skipped, err := tracks.Records(nil)
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 1)
+			So(records[0].Line, ShouldEqual, 3)
+			So(skipped, ShouldResemble, []importer.Skip{{Track: late, Reason: importer.ErrNextDay}})
+		})
+
+		Convey("with times", func() {
+			at := func(day, hour, minute int) time.Time {
+				return time.Date(2021, time.May, day, hour, minute, 0, 0, time.Local)
+			}
+
+			record, err := importer.Record{}.WithTimes(at(13, 23, 0), at(14, 0, 0))
+			So(errors.Is(err, importer.ErrNextDay), ShouldBeTrue)
+			So(record.Date, ShouldBeEmpty)
+
+			_, err = importer.Record{}.WithTimes(at(13, 9, 4), at(13, 9, 1))
+			So(errors.Is(err, importer.ErrTooShort), ShouldBeTrue)
+
+			record, err = importer.Record{}.WithTimes(at(13, 9, 4), at(13, 9, 55))
+			So(err, ShouldBeNil)
+			So(record, ShouldResemble, importer.Record{Date: "2021-05-13", From: "09:00", To: "10:00"})
+		})
+
+		Convey("unknown", func() {
+			_, err := importer.ReadTrackerFile("harvest", write("h.csv", togglCSV))
+			So(errors.Is(err, importer.ErrUnknownTracker), ShouldBeTrue)
+
+			_, err = importer.ReadTrackerFile("toggl", write("bad.csv", "date,message\n"))
+			So(errors.Is(err, importer.ErrNoColumn), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,24 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteYAML writes records in the form ReadYAML reads them.
+func WriteYAML(w io.Writer, records Records) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+
+	return nil
+}
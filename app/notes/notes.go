@@ -0,0 +1,192 @@
+// Package notes keeps the notes taken during the day, one plain text file per day.
+package notes
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+const (
+	dirName = "notes"
+	tag     = "@"
+)
+
+var (
+	ErrEmpty = errors.New("empty note")
+)
+
+// Note is a line in the log: the time it was taken, the optional project tag and the text, e.g.
+//
+//	10:40 @egg fixed flaky test
+type Note struct {
+	At      time.Time
+	Project string
+	Text    string
+}
+
+func (n Note) String() string {
+	s := n.At.Format("15:04") + " "
+	if len(n.Project) > 0 {
+		s += tag + n.Project + " "
+	}
+
+	return s + n.Text
+}
+
+type Notes []Note
+
+// Message joins the texts of the notes, one per line.
+func (n Notes) Message() string {
+	texts := make([]string, 0, len(n))
+	for _, note := range n {
+		texts = append(texts, note.Text)
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// ByProject groups the notes by the project tag, ignoring the case, in the order the projects were first noted.
+// Notes without the tag go to the group of the given project.
+func (n Notes) ByProject(untagged string) []Notes {
+	var (
+		groups []Notes
+		index  = map[string]int{}
+	)
+
+	for _, note := range n {
+		if len(note.Project) == 0 {
+			note.Project = untagged
+		}
+
+		key := strings.ToLower(note.Project)
+
+		idx, ok := index[key]
+		if !ok {
+			idx = len(groups)
+			index[key] = idx
+			groups = append(groups, nil)
+		}
+
+		groups[idx] = append(groups[idx], note)
+	}
+
+	return groups
+}
+
+// Log stores the notes in the directory, usually next to the config.
+type Log struct {
+	dir string
+}
+
+func New(dir string) Log {
+	return Log{dir: filepath.Join(dir, dirName)}
+}
+
+// Add appends the note to the log of its day. Project tag is taken from the text, if not set.
+// Lines of a multi-line text are folded into one, as the log keeps a note per line.
+func (l Log) Add(note Note) error {
+	note.Text = fold(note.Text)
+
+	if len(note.Project) == 0 {
+		note = parseText(note.At, note.Text)
+	}
+
+	if len(strings.TrimSpace(note.Text)) == 0 {
+		return ErrEmpty
+	}
+
+	if err := os.MkdirAll(l.dir, 0700); err != nil {
+		return fmt.Errorf("mkdir all: %w", err)
+	}
+
+	sock, err := os.OpenFile(l.path(types.Date{Time: note.At}), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+
+	if _, err = sock.WriteString(note.String() + "\n"); err != nil {
+		_ = sock.Close()
+
+		return fmt.Errorf("write string: %w", err)
+	}
+
+	if err = sock.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+
+	return nil
+}
+
+// Day returns the notes taken on the day.
+func (l Log) Day(date types.Date) (Notes, error) {
+	sock, err := os.Open(l.path(date))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer func() { _ = sock.Close() }()
+
+	var (
+		notes   Notes
+		scanner = bufio.NewScanner(sock)
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		clock, text, _ := strings.Cut(scanner.Text(), " ")
+		if len(strings.TrimSpace(clock)) == 0 {
+			continue
+		}
+
+		at, err := time.ParseInLocation("15:04", clock, date.Location())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		at = time.Date(date.Year(), date.Month(), date.Day(), at.Hour(), at.Minute(), 0, 0, date.Location())
+		notes = append(notes, parseText(at, text))
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return notes, nil
+}
+
+func (l Log) path(date types.Date) string {
+	return filepath.Join(l.dir, date.Format("2006-01-02")+".log")
+}
+
+func parseText(at time.Time, text string) Note {
+	note := Note{At: at, Text: strings.TrimSpace(text)}
+
+	if first, rest, _ := strings.Cut(note.Text, " "); strings.HasPrefix(first, tag) && len(first) > len(tag) {
+		note.Project, note.Text = strings.TrimPrefix(first, tag), strings.TrimSpace(rest)
+	}
+
+	return note
+}
+
+func fold(text string) string {
+	lines := strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' })
+	folded := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			folded = append(folded, line)
+		}
+	}
+
+	return strings.Join(folded, " ")
+}
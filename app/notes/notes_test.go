@@ -0,0 +1,59 @@
+package notes_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/notes"
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLog(t *testing.T) {
+	Convey("Log", t, func() {
+		log := notes.New(t.TempDir())
+		day := types.Date{Time: time.Date(2021, time.May, 13, 0, 0, 0, 0, time.Local)}
+		at := func(hour, minute int) time.Time {
+			return time.Date(2021, time.May, 13, hour, minute, 0, 0, time.Local)
+		}
+
+		list, err := log.Day(day)
+		So(err, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+
+		So(log.Add(notes.Note{At: at(9, 15), Text: "fixed flaky test"}), ShouldBeNil)
+		So(log.Add(notes.Note{At: at(11, 40), Text: "@k4s deployed"}), ShouldBeNil)
+		So(log.Add(notes.Note{At: at(14, 5), Project: "Egg", Text: "reviewed PRs"}), ShouldBeNil)
+		So(log.Add(notes.Note{At: at(14, 5), Text: "@egg "}), ShouldBeError)
+		So(errors.Is(log.Add(notes.Note{At: at(14, 5), Text: " "}), notes.ErrEmpty), ShouldBeTrue)
+
+		So(log.Add(notes.Note{At: at(9, 0).AddDate(0, 0, 1), Text: "next day"}), ShouldBeNil)
+
+		list, err = log.Day(day)
+		So(err, ShouldBeNil)
+		So(list, ShouldResemble, notes.Notes{
+			{At: at(9, 15), Text: "fixed flaky test"},
+			{At: at(11, 40), Project: "k4s", Text: "deployed"},
+			{At: at(14, 5), Project: "Egg", Text: "reviewed PRs"},
+		})
+
+		So(list.Message(), ShouldEqual, "fixed flaky test\ndeployed\nreviewed PRs")
+		So(list[1].String(), ShouldEqual, "11:40 @k4s deployed")
+
+		groups := list.ByProject("egg")
+		So(groups, ShouldHaveLength, 2)
+		So(groups[0].Message(), ShouldEqual, "fixed flaky test\nreviewed PRs")
+		So(groups[0][0].Project, ShouldEqual, "egg")
+		So(groups[1][0].Project, ShouldEqual, "k4s")
+
+		Convey("multi-line note", func() {
+			So(log.Add(notes.Note{At: at(16, 30), Text: "@k4s rolled back\r\n\n  the release\n"}), ShouldBeNil)
+
+			list, err := log.Day(day)
+			So(err, ShouldBeNil)
+			So(list, ShouldHaveLength, 4)
+			So(list[3], ShouldResemble, notes.Note{At: at(16, 30), Project: "k4s", Text: "rolled back the release"})
+		})
+	})
+}
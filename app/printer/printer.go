@@ -1,13 +1,137 @@
 package printer
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+	OutputCSV  = "csv"
+)
+
+var (
+	ErrUnknownOutput = errors.New("unknown output, use text, json, yaml or csv")
+	ErrNotTabular    = errors.New("cannot be printed as csv")
 )
 
+// Tabular is the data printable as CSV: the header and the rows under it.
+type Tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// Viewer is the data with the stable form for scripts, the view, that is printed as JSON or YAML in its place.
+type Viewer interface {
+	View() interface{}
+}
+
 type Printer struct {
 	W io.Writer
 	E io.Writer
+
+	// Output is the format Value prints in, text if not set. It is set by the global flag once the app runs,
+	// after the printer is handed to the commands, hence the pointer.
+	Output *string
+}
+
+// TestOutput checks that the output is known.
+func TestOutput(output string) error {
+	switch output {
+	case OutputText, OutputJSON, OutputYAML, OutputCSV:
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", output, ErrUnknownOutput)
+}
+
+// IsText tells if the output is for humans rather than scripts.
+func (p Printer) IsText() bool {
+	return p.output() == OutputText
+}
+
+// Value prints the value in the output format: as is for text, or as structured data.
+func (p Printer) Value(v interface{}) error {
+	switch output := p.output(); output {
+	case OutputText:
+		p.Println(v)
+
+	case OutputJSON:
+		v = nonNil(view(v))
+		encoder := json.NewEncoder(p.W)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(v); err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+
+	case OutputYAML:
+		v = nonNil(view(v))
+		encoder := yaml.NewEncoder(p.W)
+		encoder.SetIndent(2)
+
+		if err := encoder.Encode(v); err != nil {
+			return fmt.Errorf("encode yaml: %w", err)
+		}
+
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("close yaml: %w", err)
+		}
+
+	case OutputCSV:
+		tabular, ok := v.(Tabular)
+		if !ok {
+			return fmt.Errorf("%T: %w", v, ErrNotTabular)
+		}
+
+		writer := csv.NewWriter(p.W)
+
+		if err := writer.Write(tabular.Header()); err != nil {
+			return fmt.Errorf("write header: %w", err)
+		}
+
+		if err := writer.WriteAll(tabular.Rows()); err != nil {
+			return fmt.Errorf("write rows: %w", err)
+		}
+
+	default:
+		return fmt.Errorf("%s: %w", output, ErrUnknownOutput)
+	}
+
+	return nil
+}
+
+func view(v interface{}) interface{} {
+	if viewer, ok := v.(Viewer); ok {
+		return viewer.View()
+	}
+
+	return v
+}
+
+// nonNil makes nil slices empty, so that lists are always lists in the output.
+func nonNil(v interface{}) interface{} {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		return reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+
+	return v
+}
+
+func (p Printer) output() string {
+	if p.Output == nil || len(*p.Output) == 0 {
+		return OutputText
+	}
+
+	return *p.Output
 }
 
 func (p Printer) Print(a ...interface{}) {
@@ -23,13 +147,13 @@ func (p Printer) Printf(format string, a ...interface{}) {
 }
 
 func (p Printer) ErrPrint(a ...interface{}) {
-	_, _ = fmt.Fprint(p.W, a...)
+	_, _ = fmt.Fprint(p.E, a...)
 }
 
 func (p Printer) ErrPrintln(a ...interface{}) {
-	_, _ = fmt.Fprintln(p.W, a...)
+	_, _ = fmt.Fprintln(p.E, a...)
 }
 
 func (p Printer) ErrPrintf(format string, a ...interface{}) {
-	_, _ = fmt.Fprintf(p.W, format, a...)
+	_, _ = fmt.Fprintf(p.E, format, a...)
 }
@@ -0,0 +1,123 @@
+package printer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kudrykv/go-vkpm/app/printer"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type row struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+type rows []row
+
+func (r rows) Header() []string {
+	return []string{"name"}
+}
+
+func (r rows) Rows() [][]string {
+	out := make([][]string, 0, len(r))
+	for _, one := range r {
+		out = append(out, []string{one.Name})
+	}
+
+	return out
+}
+
+func (r rows) String() string {
+	return "rows"
+}
+
+// viewed is printed as its view rather than as it is.
+type viewed struct {
+	Name string
+}
+
+func (v viewed) View() interface{} {
+	return row{Name: "VIEW " + v.Name}
+}
+
+func TestPrinter_Value(t *testing.T) {
+	Convey("Value", t, func() {
+		out := &bytes.Buffer{}
+		output := ""
+		p := printer.Printer{W: out, E: out, Output: &output}
+		value := rows{{Name: "egg"}, {Name: "a, b"}}
+
+		Convey("text by default", func() {
+			So(p.IsText(), ShouldBeTrue)
+			So(p.Value(value), ShouldBeNil)
+			So(out.String(), ShouldEqual, "rows\n")
+		})
+
+		Convey("json", func() {
+			output = printer.OutputJSON
+			So(p.Value(value), ShouldBeNil)
+			So(out.String(), ShouldEqual, "[\n  {\n    \"name\": \"egg\"\n  },\n  {\n    \"name\": \"a, b\"\n  }\n]\n")
+
+			out.Reset()
+			So(p.Value(rows(nil)), ShouldBeNil)
+			So(out.String(), ShouldEqual, "[]\n")
+
+			out.Reset()
+			So(p.Value(viewed{Name: "egg"}), ShouldBeNil)
+			So(out.String(), ShouldEqual, "{\n  \"name\": \"VIEW egg\"\n}\n")
+		})
+
+		Convey("yaml", func() {
+			output = printer.OutputYAML
+			So(p.Value(value), ShouldBeNil)
+			So(out.String(), ShouldEqual, "- name: egg\n- name: a, b\n")
+
+			out.Reset()
+			So(p.Value(viewed{Name: "egg"}), ShouldBeNil)
+			So(out.String(), ShouldEqual, "name: VIEW egg\n")
+		})
+
+		Convey("csv", func() {
+			output = printer.OutputCSV
+			So(p.Value(value), ShouldBeNil)
+			So(out.String(), ShouldEqual, "name\negg\n\"a, b\"\n")
+
+			So(errors.Is(p.Value(row{}), printer.ErrNotTabular), ShouldBeTrue)
+		})
+
+		Convey("format", func() {
+			So(p.Format(value, "- {{upper .Name}}"), ShouldBeNil)
+			So(out.String(), ShouldEqual, "- EGG\n- A, B\n")
+
+			out.Reset()
+			So(p.Format(row{Name: "egg"}, "{{json .}}"), ShouldBeNil)
+			So(out.String(), ShouldEqual, `{"name":"egg"}`+"\n")
+
+			So(p.Format(value, "{{.Name"), ShouldBeError)
+			So(p.Format(value, "{{.Nope}}"), ShouldBeError)
+		})
+
+		Convey("unknown", func() {
+			So(errors.Is(printer.TestOutput("xml"), printer.ErrUnknownOutput), ShouldBeTrue)
+			So(printer.TestOutput(printer.OutputCSV), ShouldBeNil)
+
+			output = "xml"
+			So(errors.Is(p.Value(value), printer.ErrUnknownOutput), ShouldBeTrue)
+		})
+	})
+}
+
+func TestPrinter_ErrPrint(t *testing.T) {
+	Convey("ErrPrint writes to E, apart from the output", t, func() {
+		out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+		p := printer.Printer{W: out, E: errOut}
+
+		p.ErrPrint("username: ")
+		p.ErrPrintln("session expired")
+		p.ErrPrintf("%d) %s\n", 1, "Egg Inc.")
+
+		So(errOut.String(), ShouldEqual, "username: session expired\n1) Egg Inc.\n")
+		So(out.String(), ShouldBeEmpty)
+	})
+}
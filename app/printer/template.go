@@ -0,0 +1,57 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var funcs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		bts, err := json.Marshal(view(v))
+
+		return string(bts), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"hours": func(d time.Duration) float64 { return d.Hours() },
+}
+
+// Format prints the value with the Go template, like docker ps --format does: a list is printed an item per line.
+func (p Printer) Format(v interface{}, text string) error {
+	tmpl, err := template.New("format").Funcs(funcs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return p.execute(tmpl, v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err = p.execute(tmpl, rv.Index(i).Interface()); err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// execute prints nothing if the template fails, so that the output is not cut in the middle of the line.
+func (p Printer) execute(tmpl *template.Template, v interface{}) error {
+	buf := bytes.Buffer{}
+
+	if err := tmpl.Execute(&buf, v); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	p.Println(buf.String())
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"runtime/trace"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -24,12 +25,19 @@ type API struct {
 	cfg     config.Config
 	cookies config.Cookies
 
+	credentials Credentials
+	onRenew     func(config.Cookies) error
+	authMutex   *sync.Mutex
+
 	blocksOn   bool
 	mutex      *sync.Mutex
 	semaphore  chan struct{}
 	littleHTTP *littlehttp.LittleHTTP
 }
 
+// Credentials provides username and password to sign in again once the session expires.
+type Credentials func(ctx context.Context) (string, string, error)
+
 var (
 	ErrBadCreds  = errors.New("bad credentials")
 	ErrNoNode    = errors.New("node not found")
@@ -37,6 +45,8 @@ var (
 	ErrNonEmpty  = errors.New("expected empty response")
 	ErrBadStatus = errors.New("bad status")
 	ErrNoReport  = errors.New("no report found")
+
+	ErrSessionExpired = errors.New("session expired, sign in again")
 )
 
 func NewAPI(littleHTTP *littlehttp.LittleHTTP, cfg config.Config) *API {
@@ -44,15 +54,28 @@ func NewAPI(littleHTTP *littlehttp.LittleHTTP, cfg config.Config) *API {
 		littleHTTP: littleHTTP,
 		cfg:        cfg,
 		mutex:      &sync.Mutex{},
+		authMutex:  &sync.Mutex{},
 		semaphore:  make(chan struct{}, 4),
 	}
 }
 
 func (a *API) WithCookies(c config.Cookies) *API {
+	a.authMutex.Lock()
+	defer a.authMutex.Unlock()
+
 	a.cookies = c
 	return a
 }
 
+// WithRelogin makes the API sign in again and retry the request once, when the session expires.
+// onRenew is called with the new cookies, so they could be persisted.
+func (a *API) WithRelogin(credentials Credentials, onRenew func(config.Cookies) error) *API {
+	a.credentials = credentials
+	a.onRenew = onRenew
+
+	return a
+}
+
 func (a *API) Login(ctx context.Context, username, password string) (config.Cookies, error) {
 	ctx, end := th.RegionTask(ctx, "login")
 	defer end()
@@ -219,7 +242,7 @@ func (a *API) Report(ctx context.Context, entry types.ReportEntry) (types.Report
 		return entry, fmt.Errorf("url values: %w", err)
 	}
 
-	bts, resp, err := a.do(ctx, http.MethodPost, "/report/", body, a.h())
+	bts, resp, err := a.doSession(ctx, http.MethodPost, "/report/", body)
 	if err != nil {
 		return entry, fmt.Errorf("do: %w", err)
 	}
@@ -233,16 +256,12 @@ func (a *API) Report(ctx context.Context, entry types.ReportEntry) (types.Report
 		return entry, fmt.Errorf("history: %w", err)
 	}
 
-	today := history.FindLatestForToday(entry.ReportDate)
-	if today == nil {
-		return entry, fmt.Errorf("did not find reported: %w", ErrNoReport)
-	}
-
-	if !today.IsSame(entry) {
+	reported := history.FindSame(entry)
+	if reported == nil {
 		return entry, fmt.Errorf("report didn't work: %w", ErrNoReport)
 	}
 
-	return *today, nil
+	return *reported, nil
 }
 
 func (a *API) PersonInfo(ctx context.Context, id int) (types.Person, error) {
@@ -265,7 +284,7 @@ func (a *API) PersonInfo(ctx context.Context, id int) (types.Person, error) {
 }
 
 func (a *API) GetPicture(ctx context.Context, uri string) ([]byte, error) {
-	bts, _, err := a.do(ctx, http.MethodGet, uri, nil, a.h())
+	bts, _, err := a.doSession(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("do: %w", err)
 	}
@@ -276,7 +295,7 @@ func (a *API) GetPicture(ctx context.Context, uri string) ([]byte, error) {
 func (a *API) doParse(ctx context.Context, method, url string, body url.Values) (*html.Node, error) {
 	defer trace.StartRegion(ctx, "do and parse").End()
 
-	bts, resp, err := a.do(ctx, method, url, body, a.h())
+	bts, resp, err := a.doSession(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("do: %w", err)
 	}
@@ -307,9 +326,7 @@ func (a *API) allBlocksOn(ctx context.Context) error {
 		return nil
 	}
 
-	h := a.h()
-
-	bts, _, err := a.do(ctx, http.MethodGet, "/dashboard/", nil, h)
+	bts, _, err := a.doSession(ctx, http.MethodGet, "/dashboard/", nil)
 	if err != nil {
 		return fmt.Errorf("do dashboard: %w", err)
 	}
@@ -347,7 +364,7 @@ func (a *API) allBlocksOn(ctx context.Context) error {
 		"users_block":       {"on"},
 	}
 
-	bts, _, err = a.do(ctx, http.MethodPost, "/dashboard/update/", values, h)
+	bts, _, err = a.doSession(ctx, http.MethodPost, "/dashboard/update/", values)
 	if err != nil {
 		return fmt.Errorf("do dashboard update: %w", err)
 	}
@@ -361,12 +378,123 @@ func (a *API) allBlocksOn(ctx context.Context) error {
 	return nil
 }
 
-func (a *API) h() http.Header {
+func (a *API) h(cookies config.Cookies) http.Header {
 	return http.Header{
-		"Cookie":      {"csrftoken=" + a.cookies.CSRFToken + "; sessionid=" + a.cookies.SessionID},
+		"Cookie":      {"csrftoken=" + cookies.CSRFToken + "; sessionid=" + cookies.SessionID},
 		"Referer":     {"https://" + a.cfg.Domain + "/dashboard/"},
-		"x-csrftoken": {a.cookies.CSRFToken},
+		"x-csrftoken": {cookies.CSRFToken},
+	}
+}
+
+func (a *API) currentCookies() config.Cookies {
+	a.authMutex.Lock()
+	defer a.authMutex.Unlock()
+
+	return a.cookies
+}
+
+func (a *API) renew(ctx context.Context, expired config.Cookies) (config.Cookies, error) {
+	defer trace.StartRegion(ctx, "renew session").End()
+
+	a.authMutex.Lock()
+	defer a.authMutex.Unlock()
+
+	if a.cookies != expired {
+		return a.cookies, nil
+	}
+
+	username, password, err := a.credentials(ctx)
+	if err != nil {
+		return expired, fmt.Errorf("credentials: %w", err)
+	}
+
+	cookies, err := a.Login(ctx, username, password)
+	if err != nil {
+		return expired, fmt.Errorf("login: %w", err)
+	}
+
+	a.cookies = cookies
+
+	if a.onRenew != nil {
+		if err = a.onRenew(cookies); err != nil {
+			return cookies, fmt.Errorf("on renew: %w", err)
+		}
+	}
+
+	return cookies, nil
+}
+
+// doSession does the request on behalf of the signed in user, renewing the session if it has expired.
+func (a *API) doSession(
+	ctx context.Context, method, url string, body url.Values,
+) ([]byte, *http.Response, error) {
+	cookies := a.currentCookies()
+
+	bts, resp, err := a.do(ctx, method, url, body, a.h(cookies))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isSessionExpired(resp, bts) {
+		return bts, resp, nil
+	}
+
+	if a.credentials == nil {
+		return nil, nil, fmt.Errorf("%s %s: %w", method, url, ErrSessionExpired)
+	}
+
+	if cookies, err = a.renew(ctx, cookies); err != nil {
+		return nil, nil, fmt.Errorf("%s %s: %w", method, url, renewError{err: err})
 	}
+
+	if bts, resp, err = a.do(ctx, method, url, body, a.h(cookies)); err != nil {
+		return nil, nil, err
+	}
+
+	if isSessionExpired(resp, bts) {
+		return nil, nil, fmt.Errorf("%s %s after renewal: %w", method, url, ErrSessionExpired)
+	}
+
+	return bts, resp, nil
+}
+
+// renewError is the failure to renew the expired session. It is both ErrSessionExpired and the reason
+// the renewal failed, e.g. ErrBadCreds.
+type renewError struct {
+	err error
+}
+
+func (e renewError) Error() string {
+	return "renew: " + e.err.Error()
+}
+
+func (e renewError) Unwrap() error {
+	return e.err
+}
+
+func (e renewError) Is(target error) bool {
+	return target == ErrSessionExpired
+}
+
+// isSessionExpired tells if the site sent us to sign in: either redirected to the login page,
+// or responded with the login form. Other forms with a password field, if any, do not count.
+func isSessionExpired(resp *http.Response, bts []byte) bool {
+	if resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode < http.StatusBadRequest {
+		if location, err := resp.Location(); err == nil && strings.HasPrefix(location.Path, "/login/") {
+			return true
+		}
+	}
+
+	if !bytes.Contains(bts, []byte(`name="password"`)) {
+		return false
+	}
+
+	doc, err := htmlquery.Parse(bytes.NewReader(bts))
+	if err != nil {
+		return false
+	}
+
+	return htmlquery.FindOne(doc, `//form[contains(@action, "/login/")]//input[@name="password"]`) != nil
 }
 
 func (a *API) getCookies(ctx context.Context) (string, error) {
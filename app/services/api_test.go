@@ -0,0 +1,242 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/services"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/kudrykv/go-vkpm/app/vkpmtest"
+	"github.com/kudrykv/littlehttp"
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/sync/errgroup"
+)
+
+func TestAPI(t *testing.T) {
+	Convey("API", t, func() {
+		ctx := context.Background()
+		egg := types.Project{ID: "7", Name: "Egg Inc."}
+		k4s := types.Project{ID: "9", Name: "Kube For Startups"}
+		may := time.Date(2021, time.May, 13, 0, 0, 0, 0, time.UTC)
+
+		server := vkpmtest.NewServer().
+			WithUser("john", "secret").
+			WithProjects(egg, k4s).
+			WithEntries(types.ReportEntry{
+				ReportDate: types.Date{Time: may}, Project: egg, Activity: types.ActivityDevelopment,
+				Name: "Egg Inc.", Description: "morning", Status: 100,
+				StartTime: clock(9, 0), EndTime: clock(10, 30),
+			}).
+			WithSalaries(types.Salary{
+				Year: 2021, Month: time.May, RatePerHour: 10, Rate: 1600, WorkingDaysInMonth: 20,
+				HoursByCurrDay: 80, DollarsByCurrDay: 800, ExpectedSalary: 1600, Total: 1600, Paid: 1500,
+			}).
+			WithVacations(12, types.Vacation{
+				ID: "3", Type: "Vacation", Status: "Approved", Paid: true,
+				StartDate: types.Date{Time: may}, EndDate: types.Date{Time: may.AddDate(0, 0, 1)}, Span: 48 * time.Hour,
+			}).
+			WithHolidays(types.Holiday{Name: "Labour Day", Date: types.Date{Time: may.AddDate(0, 0, -10)}}).
+			WithPersons(types.Person{
+				ID: 12, Name: "Jane Doe", Team: "Mobile", Email: "jane@example.com",
+				Birthday: time.Date(0, time.March, 8, 0, 0, 0, 0, time.UTC),
+			})
+		defer server.Close()
+
+		cookies := server.Session("john")
+		api := services.NewAPI(server.LittleHTTP(), server.Config(cookies)).WithCookies(cookies)
+
+		Convey("login", func() {
+			anon := services.NewAPI(server.LittleHTTP(), server.Config(config.Cookies{}))
+
+			Convey("good credentials", func() {
+				got, err := anon.Login(ctx, "john\n", "secret")
+				So(err, ShouldBeNil)
+				So(got.IsZero(), ShouldBeFalse)
+
+				_, err = anon.WithCookies(got).Projects(ctx)
+				So(err, ShouldBeNil)
+			})
+
+			Convey("bad credentials", func() {
+				_, err := anon.Login(ctx, "john", "wrong")
+				So(err, ShouldBeError, services.ErrBadCreds)
+			})
+		})
+
+		Convey("no session", func() {
+			_, err := services.NewAPI(server.LittleHTTP(), server.Config(config.Cookies{})).History(ctx, 2021, time.May)
+			So(errors.Is(err, services.ErrSessionExpired), ShouldBeTrue)
+		})
+
+		Convey("expired session", func() {
+			server.ExpireSessions()
+
+			Convey("fails without relogin", func() {
+				_, err := api.Projects(ctx)
+				So(errors.Is(err, services.ErrSessionExpired), ShouldBeTrue)
+
+				_, err = api.Report(ctx, types.ReportEntry{
+					ReportDate: types.Date{Time: may}, Project: egg, Activity: types.ActivityDevelopment,
+					Name: "Egg Inc.", Description: "evening", Status: 100, StartTime: clock(18, 0), EndTime: clock(19, 0),
+				})
+				So(errors.Is(err, services.ErrSessionExpired), ShouldBeTrue)
+			})
+
+			Convey("renews once and retries", func() {
+				var renewed []config.Cookies
+
+				api.WithRelogin(
+					func(context.Context) (string, string, error) { return "john", "secret", nil },
+					func(cookies config.Cookies) error {
+						renewed = append(renewed, cookies)
+
+						return nil
+					},
+				)
+
+				group, cctx := errgroup.WithContext(ctx)
+				for i := 0; i < 4; i++ {
+					group.Go(func() error {
+						_, err := api.Salary(cctx, 2021, time.May)
+
+						return err
+					})
+				}
+
+				So(group.Wait(), ShouldBeNil)
+				So(renewed, ShouldHaveLength, 1)
+				So(server.Count("POST /login/"), ShouldEqual, 1)
+			})
+
+			Convey("gives up on bad credentials", func() {
+				api.WithRelogin(func(context.Context) (string, string, error) { return "john", "wrong", nil }, nil)
+
+				_, err := api.History(ctx, 2021, time.May)
+				So(errors.Is(err, services.ErrBadCreds), ShouldBeTrue)
+				So(errors.Is(err, services.ErrSessionExpired), ShouldBeTrue)
+			})
+		})
+
+		Convey("salary turns dashboard blocks on once", func() {
+			salary, err := api.Salary(ctx, 2021, time.May)
+			So(err, ShouldBeNil)
+			So(salary.Rate, ShouldEqual, 1600)
+			So(salary.HoursByCurrDay, ShouldEqual, 80)
+			So(salary.Paid, ShouldEqual, 1500)
+
+			_, err = api.Salary(ctx, 2021, time.April)
+			So(err, ShouldBeNil)
+			So(server.Count("POST /dashboard/update/"), ShouldEqual, 1)
+		})
+
+		Convey("birthdays and person info", func() {
+			persons, err := api.Birthdays(ctx)
+			So(err, ShouldBeNil)
+			So(persons, ShouldHaveLength, 1)
+			So(persons[0].ID, ShouldEqual, 12)
+			So(persons[0].Team, ShouldEqual, "Mobile")
+
+			person, err := api.PersonInfo(ctx, persons[0].ID)
+			So(err, ShouldBeNil)
+			So(person.Email, ShouldEqual, "jane@example.com")
+
+			picture, err := api.GetPicture(ctx, person.PhotoURL)
+			So(err, ShouldBeNil)
+			So(picture, ShouldNotBeEmpty)
+		})
+
+		Convey("vacations and holidays", func() {
+			paid, vacations, holidays, err := api.VacationsHolidays(ctx, 2021)
+			So(err, ShouldBeNil)
+			So(paid, ShouldEqual, 12)
+			So(vacations, ShouldHaveLength, 1)
+			So(vacations.Vacated(types.Date{Time: may.AddDate(0, 0, 1)}), ShouldBeTrue)
+			So(holidays, ShouldHaveLength, 1)
+			So(holidays[0].Name, ShouldEqual, "Labour Day")
+		})
+
+		Convey("report", func() {
+			projects, err := api.Projects(ctx)
+			So(err, ShouldBeNil)
+			So(projects, ShouldResemble, types.Projects{egg, k4s})
+
+			history, err := api.History(ctx, 2021, time.May)
+			So(err, ShouldBeNil)
+			So(history, ShouldHaveLength, 1)
+			So(history[0].Span, ShouldEqual, 90*time.Minute)
+
+			entry := types.ReportEntry{
+				ReportDate: types.Date{Time: may}, Project: types.Project{Name: "kube"},
+				Activity: types.ActivityAnalysis, Description: "reading specs", Status: 50, Span: 2 * time.Hour,
+			}
+
+			entry, err = entry.UpdateProjectName(projects, nil)
+			So(err, ShouldBeNil)
+
+			Convey("stacks after the latest entry", func() {
+				entries, err := entry.AlignTimes(history, types.DefaultWorkday())
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 1)
+
+				reported, err := api.Report(ctx, entries[0])
+				So(err, ShouldBeNil)
+				So(reported.ID, ShouldNotBeEmpty)
+				So(reported.StartTime.Format("15:04"), ShouldEqual, "10:30")
+				So(reported.EndTime.Format("15:04"), ShouldEqual, "12:30")
+				So(server.Entries(), ShouldHaveLength, 2)
+			})
+
+			Convey("rejected by the server", func() {
+				entry.StartTime, entry.EndTime = clock(10, 0), clock(11, 0)
+
+				_, err = api.Report(ctx, entry)
+				So(errors.Is(err, services.ErrBadStatus), ShouldBeTrue)
+				So(server.Entries(), ShouldHaveLength, 1)
+			})
+		})
+	})
+}
+
+func TestAPI_SessionExpired(t *testing.T) {
+	Convey("session expiry", t, func() {
+		ctx := context.Background()
+		pages := map[string]string{
+			"/history/": `<form action="/profile/password/"><input type="password" name="password"></form>`,
+			"/report/":  `<form method="post" action="/login/"><input type="password" name="password"></form>`,
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("<html><body>" + pages[r.URL.Path] + "</body></html>"))
+		}))
+		defer server.Close()
+
+		client, err := littlehttp.New(littlehttp.Parameters{
+			Client:     server.Client(),
+			URLPrefix:  server.URL,
+			Marshaller: func(src any) (string, []byte, error) { return "text/plain", nil, nil },
+		})
+		So(err, ShouldBeNil)
+
+		api := services.NewAPI(client, config.Config{Domain: strings.TrimPrefix(server.URL, "http://")})
+
+		Convey("not by any password field", func() {
+			_, err := api.History(ctx, 2021, time.May)
+			So(errors.Is(err, services.ErrSessionExpired), ShouldBeFalse)
+		})
+
+		Convey("by the login form", func() {
+			_, err := api.Projects(ctx)
+			So(errors.Is(err, services.ErrSessionExpired), ShouldBeTrue)
+		})
+	})
+}
+
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
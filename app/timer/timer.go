@@ -0,0 +1,203 @@
+// Package timer keeps the running timer and the stopped, not yet reported ones in files.
+package timer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	stateFile   = "timer.yml"
+	pendingFile = "timer_pending.yml"
+)
+
+var (
+	ErrRunning    = errors.New("timer is already running")
+	ErrNotRunning = errors.New("timer is not running")
+	ErrTooShort   = errors.New("less than 10 minutes tracked")
+	ErrNextDay    = errors.New("timer ran over midnight, report it manually")
+)
+
+// State is the running timer.
+type State struct {
+	Project  string    `yaml:"project,omitempty"`
+	Activity string    `yaml:"activity,omitempty"`
+	Title    string    `yaml:"title,omitempty"`
+	Message  string    `yaml:"message"`
+	Started  time.Time `yaml:"started"`
+}
+
+// Record makes the record for the time from the start till stopped, both rounded to 10 minutes.
+func (s State) Record(stopped time.Time) (importer.Record, error) {
+	y1, m1, d1 := s.Started.Date()
+	y2, m2, d2 := stopped.Date()
+
+	if y1 != y2 || m1 != m2 || d1 != d2 {
+		return importer.Record{}, ErrNextDay
+	}
+
+	from, to := roundClock(s.Started), roundClock(stopped)
+	if to <= from {
+		return importer.Record{}, fmt.Errorf("%s-%s: %w", s.Started.Format("15:04"), stopped.Format("15:04"), ErrTooShort)
+	}
+
+	return importer.Record{
+		Date:     s.Started.Format("2006-01-02"),
+		Project:  s.Project,
+		Activity: s.Activity,
+		From:     formatClock(from),
+		To:       formatClock(to),
+		Title:    s.Title,
+		Message:  s.Message,
+	}, nil
+}
+
+// Timer stores the state in the directory, usually next to the config.
+type Timer struct {
+	dir string
+}
+
+func New(dir string) Timer {
+	return Timer{dir: dir}
+}
+
+// State returns the running timer, or nil if there is none.
+func (t Timer) State() (*State, error) {
+	bts, err := ioutil.ReadFile(t.path(stateFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	var state State
+	if err = yaml.Unmarshal(bts, &state); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &state, nil
+}
+
+func (t Timer) Start(state State) error {
+	running, err := t.State()
+	if err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+
+	if running != nil {
+		return fmt.Errorf("since %s: %w", running.Started.Format("15:04"), ErrRunning)
+	}
+
+	bts, err := yaml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err = ioutil.WriteFile(t.path(stateFile), bts, 0600); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	return nil
+}
+
+// Stop removes the running timer and returns it.
+func (t Timer) Stop() (State, error) {
+	running, err := t.State()
+	if err != nil {
+		return State{}, fmt.Errorf("state: %w", err)
+	}
+
+	if running == nil {
+		return State{}, ErrNotRunning
+	}
+
+	if err = os.Remove(t.path(stateFile)); err != nil {
+		return State{}, fmt.Errorf("remove: %w", err)
+	}
+
+	return *running, nil
+}
+
+// Pending returns the records kept to be reported later.
+func (t Timer) Pending() (importer.Records, error) {
+	bts, err := ioutil.ReadFile(t.path(pendingFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	records, err := importer.ReadYAML(bytes.NewReader(bts))
+	if err != nil {
+		return nil, fmt.Errorf("read yaml: %w", err)
+	}
+
+	return records, nil
+}
+
+// Keep adds the record to the pending ones.
+func (t Timer) Keep(record importer.Record) error {
+	records, err := t.Pending()
+	if err != nil {
+		return fmt.Errorf("pending: %w", err)
+	}
+
+	return t.SetPending(append(records, record))
+}
+
+// SetPending replaces the pending records, removing the file if there are none.
+func (t Timer) SetPending(records importer.Records) error {
+	if len(records) == 0 {
+		if err := os.Remove(t.path(pendingFile)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove: %w", err)
+		}
+
+		return nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := importer.WriteYAML(buf, records); err != nil {
+		return fmt.Errorf("write yaml: %w", err)
+	}
+
+	if err := ioutil.WriteFile(t.path(pendingFile), buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+
+	return nil
+}
+
+// PendingPath is the file with the pending records, which also can be reported with report import.
+func (t Timer) PendingPath() string {
+	return t.path(pendingFile)
+}
+
+func (t Timer) path(name string) string {
+	return filepath.Join(t.dir, name)
+}
+
+// roundClock returns minutes since midnight rounded to 10 minutes, not going over the end of the day.
+func roundClock(t time.Time) int {
+	minutes := (t.Hour()*60 + t.Minute() + 5) / 10 * 10
+	if minutes >= 24*60 {
+		minutes = 24*60 - 10
+	}
+
+	return minutes
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
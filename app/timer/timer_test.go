@@ -0,0 +1,80 @@
+package timer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/importer"
+	"github.com/kudrykv/go-vkpm/app/timer"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestState_Record(t *testing.T) {
+	Convey("Record", t, func() {
+		at := func(hour, minute int) time.Time {
+			return time.Date(2021, time.May, 13, hour, minute, 0, 0, time.Local)
+		}
+
+		state := timer.State{Project: "egg", Message: "fixing", Started: at(9, 4)}
+
+		Convey("rounds to 10 minutes", func() {
+			record, err := state.Record(at(10, 36))
+			So(err, ShouldBeNil)
+			So(record, ShouldResemble, importer.Record{
+				Date: "2021-05-13", Project: "egg", From: "09:00", To: "10:40", Message: "fixing",
+			})
+		})
+
+		Convey("too short", func() {
+			_, err := state.Record(at(9, 1))
+			So(errors.Is(err, timer.ErrTooShort), ShouldBeTrue)
+		})
+
+		Convey("over midnight", func() {
+			_, err := state.Record(at(9, 4).AddDate(0, 0, 1))
+			So(errors.Is(err, timer.ErrNextDay), ShouldBeTrue)
+		})
+	})
+}
+
+func TestTimer(t *testing.T) {
+	Convey("Timer", t, func() {
+		tmr := timer.New(t.TempDir())
+
+		state, err := tmr.State()
+		So(err, ShouldBeNil)
+		So(state, ShouldBeNil)
+
+		_, err = tmr.Stop()
+		So(errors.Is(err, timer.ErrNotRunning), ShouldBeTrue)
+
+		started := time.Date(2021, time.May, 13, 9, 0, 0, 0, time.UTC)
+		So(tmr.Start(timer.State{Project: "egg", Started: started}), ShouldBeNil)
+		So(errors.Is(tmr.Start(timer.State{Project: "egg"}), timer.ErrRunning), ShouldBeTrue)
+
+		stopped, err := tmr.Stop()
+		So(err, ShouldBeNil)
+		So(stopped.Started.Equal(started), ShouldBeTrue)
+
+		Convey("keeps pending records", func() {
+			So(tmr.Keep(importer.Record{Date: "2021-05-13", From: "09:00", To: "10:00", Message: "a"}), ShouldBeNil)
+			So(tmr.Keep(importer.Record{Date: "2021-05-13", From: "10:00", To: "11:00", Message: "b"}), ShouldBeNil)
+
+			pending, err := tmr.Pending()
+			So(err, ShouldBeNil)
+			So(pending, ShouldHaveLength, 2)
+			So(pending[1].Message, ShouldEqual, "b")
+
+			records, err := importer.ReadFile(tmr.PendingPath())
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 2)
+
+			So(tmr.SetPending(nil), ShouldBeNil)
+
+			pending, err = tmr.Pending()
+			So(err, ShouldBeNil)
+			So(pending, ShouldBeEmpty)
+		})
+	})
+}
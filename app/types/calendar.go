@@ -0,0 +1,306 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jwalton/gchalk"
+)
+
+const (
+	DayReported   = "reported"
+	DayUnreported = "unreported"
+	DayWeekend    = "weekend"
+	DayHoliday    = "holiday"
+	DayVacation   = "vacation"
+	DayFuture     = "future"
+)
+
+// hourLevels are the glyphs and colors of the days by the hours reported, as in GitHub contributions.
+var hourLevels = []struct {
+	below time.Duration
+	glyph string
+	color func(...string) string
+}{
+	{below: 2 * time.Hour, glyph: "░", color: gchalk.Hex("#0e4429")},
+	{below: 4 * time.Hour, glyph: "▒", color: gchalk.Hex("#006d32")},
+	{below: workdayHours, glyph: "▓", color: gchalk.Hex("#26a641")},
+	{below: 1<<63 - 1, glyph: "█", color: gchalk.Hex("#39d353")},
+}
+
+// Calendar is the heatmap of the hours reported each day from one day to another, marking weekends,
+// holidays, vacations by the type, and working days up to today with nothing reported.
+type Calendar struct {
+	from      Date
+	to        Date
+	today     Date
+	hours     map[string]time.Duration
+	vacations Vacations
+	holidays  Holidays
+}
+
+func NewCalendar(from, to Date, history ReportEntries, vacations Vacations, holidays Holidays) Calendar {
+	hours := map[string]time.Duration{}
+	for _, entries := range history.GroupByDays() {
+		hours[entries[0].ReportDate.Format(layoutDay)] += entries.Duration()
+	}
+
+	return Calendar{from: from, to: to, today: Today(), hours: hours, vacations: vacations, holidays: holidays}
+}
+
+// Day tells what the day is, one of Day* kinds; the note is the holiday name or the vacation type.
+func (c Calendar) Day(day Date) (string, string) {
+	if holiday, ok := c.holidays.On(day); ok {
+		return DayHoliday, holiday.Name
+	}
+
+	for _, vacation := range c.vacations {
+		if vacation.Vacated(day) {
+			return DayVacation, vacation.Type
+		}
+	}
+
+	switch {
+	case c.Hours(day) > 0:
+		return DayReported, ""
+	case day.IsWeekend():
+		return DayWeekend, ""
+	case day.After(c.today.Time):
+		return DayFuture, ""
+	}
+
+	return DayUnreported, ""
+}
+
+// Hours is the time reported for the day.
+func (c Calendar) Hours(day Date) time.Duration {
+	return c.hours[day.Format(layoutDay)]
+}
+
+// Unreported are the working days up to today with nothing reported.
+func (c Calendar) Unreported() Dates {
+	var days Dates
+
+	for _, day := range c.days() {
+		if kind, _ := c.Day(day); kind == DayUnreported {
+			days = append(days, day)
+		}
+	}
+
+	return days
+}
+
+// String draws the weeks as columns and the weekdays as rows; the days of a single month show their numbers.
+func (c Calendar) String() string {
+	monthly := c.from.Year() == c.to.Year() && c.from.Month() == c.to.Month()
+	monday := c.from.AddDate(0, 0, -(int(c.from.Weekday())+6)%7)
+	weeks := int(math.Round(c.to.Sub(monday.Time).Hours()/24))/7 + 1
+	builder := strings.Builder{}
+
+	width := 2
+	if monthly {
+		width = 4
+
+		builder.WriteString(gchalk.Bold(c.from.Format("January 2006")) + "\n")
+	} else {
+		builder.WriteString(gchalk.Bold(c.from.Format("2006")) + "\n" + c.monthLabels(monday, weeks) + "\n")
+	}
+
+	for weekday := 0; weekday < 7; weekday++ {
+		row := monday.AddDate(0, 0, weekday).Format("Mon") + " "
+
+		for week := 0; week < weeks; week++ {
+			day := monday.AddDate(0, 0, 7*week+weekday)
+
+			switch {
+			case day.Before(c.from.Time) || day.After(c.to.Time):
+				row += strings.Repeat(" ", width)
+			case monthly:
+				glyph, color := c.glyph(day)
+				row += color(fmt.Sprintf("%2d", day.Day())+glyph) + " "
+			default:
+				glyph, color := c.glyph(day)
+				row += color(glyph) + " "
+			}
+		}
+
+		builder.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+
+	reported, days := c.reported()
+
+	builder.WriteString("\n" + c.legend() + "\n")
+	builder.WriteString("Reported " + formatHours(reported) + " in " + strconv.Itoa(days) + " day(s)")
+
+	if unreported := c.Unreported(); len(unreported) > 0 {
+		builder.WriteString(", " + gchalk.Red(strconv.Itoa(len(unreported))+" working day(s) unreported"))
+	}
+
+	return builder.String() + "\n"
+}
+
+// monthLabels puts the month names over the weeks the months start in.
+func (c Calendar) monthLabels(monday Date, weeks int) string {
+	labels := []rune(strings.Repeat(" ", 4+2*weeks+2))
+	copy(labels[4:], []rune(c.from.Format("Jan")))
+
+	for week := 1; week < weeks; week++ {
+		for weekday := 0; weekday < 7; weekday++ {
+			if day := monday.AddDate(0, 0, 7*week+weekday); day.Day() == 1 && !day.After(c.to.Time) {
+				copy(labels[4+2*week:], []rune(day.Format("Jan")))
+			}
+		}
+	}
+
+	return strings.TrimRight(string(labels), " ")
+}
+
+func (c Calendar) glyph(day Date) (string, func(...string) string) {
+	switch kind, note := c.Day(day); kind {
+	case DayReported:
+		hours := c.Hours(day)
+		for _, level := range hourLevels {
+			if hours < level.below {
+				return level.glyph, level.color
+			}
+		}
+	case DayHoliday:
+		return "H", gchalk.Magenta
+	case DayVacation:
+		return vacationGlyph(note), gchalk.Cyan
+	case DayUnreported:
+		return "×", gchalk.Red
+	case DayWeekend:
+		return "·", gchalk.Gray
+	}
+
+	return "·", gchalk.Dim
+}
+
+func (c Calendar) legend() string {
+	parts := []string{
+		hourLevels[0].color(hourLevels[0].glyph) + " <2h",
+		hourLevels[1].color(hourLevels[1].glyph) + " <4h",
+		hourLevels[2].color(hourLevels[2].glyph) + " <8h",
+		hourLevels[3].color(hourLevels[3].glyph) + " 8h+",
+		gchalk.Red("×") + " unreported",
+		gchalk.Gray("·") + " weekend",
+		gchalk.Magenta("H") + " holiday",
+	}
+
+	vacationTypes := map[string]bool{}
+
+	for _, day := range c.days() {
+		if kind, note := c.Day(day); kind == DayVacation {
+			vacationTypes[note] = true
+		}
+	}
+
+	names := make([]string, 0, len(vacationTypes))
+	for name := range vacationTypes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		parts = append(parts, gchalk.Cyan(vacationGlyph(name))+" "+strings.ToLower(name))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+func (c Calendar) days() Dates {
+	var days Dates
+	for day := c.from; !day.After(c.to.Time); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// reported is the time reported in the calendar and the number of days it is reported in.
+func (c Calendar) reported() (time.Duration, int) {
+	var (
+		reported time.Duration
+		days     int
+	)
+
+	for _, day := range c.days() {
+		if hours := c.Hours(day); hours > 0 {
+			reported += hours
+			days++
+		}
+	}
+
+	return reported, days
+}
+
+// vacationGlyph is the first letter of the vacation type, e.g. S for sick leave.
+func vacationGlyph(vacationType string) string {
+	r, _ := utf8.DecodeRuneInString(vacationType)
+	if r == utf8.RuneError {
+		return "V"
+	}
+
+	return string(unicode.ToUpper(r))
+}
+
+type calendarDayView struct {
+	Date  string  `json:"date" yaml:"date"`
+	Kind  string  `json:"kind" yaml:"kind"`
+	Hours float64 `json:"hours" yaml:"hours"`
+	Note  string  `json:"note,omitempty" yaml:"note,omitempty"`
+}
+
+type calendarView struct {
+	From       string            `json:"from" yaml:"from"`
+	To         string            `json:"to" yaml:"to"`
+	Reported   float64           `json:"reported" yaml:"reported"`
+	Unreported []string          `json:"unreported" yaml:"unreported"`
+	Days       []calendarDayView `json:"days" yaml:"days"`
+}
+
+func (c Calendar) view() calendarView {
+	reported, _ := c.reported()
+	v := calendarView{
+		From:       c.from.Format(layoutDay),
+		To:         c.to.Format(layoutDay),
+		Reported:   reported.Hours(),
+		Unreported: c.Unreported().days(),
+	}
+
+	for _, day := range c.days() {
+		kind, note := c.Day(day)
+		v.Days = append(v.Days, calendarDayView{
+			Date: day.Format(layoutDay), Kind: kind, Hours: c.Hours(day).Hours(), Note: note,
+		})
+	}
+
+	return v
+}
+
+func (c Calendar) View() interface{} {
+	return c.view()
+}
+
+func (c Calendar) Header() []string {
+	return []string{"date", "kind", "hours", "note"}
+}
+
+func (c Calendar) Rows() [][]string {
+	v := c.view()
+	rows := make([][]string, 0, len(v.Days))
+
+	for _, day := range v.Days {
+		rows = append(rows, []string{day.Date, day.Kind, f2s(day.Hours), day.Note})
+	}
+
+	return rows
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCalendar(t *testing.T) {
+	Convey("Calendar", t, func() {
+		day := func(d int) types.Date {
+			return types.Date{Time: time.Date(2024, time.May, d, 0, 0, 0, 0, time.Local)}
+		}
+		history := types.ReportEntries{
+			{ReportDate: day(2), Span: 8 * time.Hour},
+			{ReportDate: day(3), Span: time.Hour},
+			{ReportDate: day(3), Span: 2 * time.Hour},
+		}
+		vacations := types.Vacations{{StartDate: day(7), EndDate: day(8), Span: 48 * time.Hour, Type: "Sick leave"}}
+		holidays := types.Holidays{{Name: "Labour Day", Date: day(1)}}
+
+		calendar := types.NewCalendar(day(1), day(31), history, vacations, holidays)
+
+		kinds := map[int]string{
+			1: types.DayHoliday, 2: types.DayReported, 4: types.DayWeekend, 6: types.DayUnreported,
+			7: types.DayVacation, 8: types.DayVacation, 9: types.DayUnreported,
+		}
+		for d, kind := range kinds {
+			actual, _ := calendar.Day(day(d))
+			So(actual, ShouldEqual, kind)
+		}
+
+		_, note := calendar.Day(day(7))
+		So(note, ShouldEqual, "Sick leave")
+		_, note = calendar.Day(day(1))
+		So(note, ShouldEqual, "Labour Day")
+		So(calendar.Hours(day(3)), ShouldEqual, 3*time.Hour)
+		So(calendar.Unreported(), ShouldHaveLength, 23-3-2)
+
+		text := calendar.String()
+		So(text, ShouldContainSubstring, "May 2024")
+		So(text, ShouldContainSubstring, " 1H  8S")
+		So(text, ShouldContainSubstring, "S sick leave")
+		So(text, ShouldContainSubstring, "Reported 11:00 in 2 day(s), 18 working day(s) unreported")
+
+		bts, err := json.Marshal(calendar.View())
+		So(err, ShouldBeNil)
+		So(string(bts), ShouldContainSubstring, `{"date":"2024-05-03","kind":"reported","hours":3}`)
+		So(string(bts), ShouldContainSubstring, `"unreported":["2024-05-06",`)
+	})
+}
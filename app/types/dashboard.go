@@ -0,0 +1,31 @@
+package types
+
+// Dashboard is the state of this month, with the salaries of this and the last one.
+type Dashboard struct {
+	ThisMonth Salary
+	LastMonth Salary
+	Month     MonthInfo
+}
+
+func (d Dashboard) String() string {
+	return d.ThisMonth.StringTotalPaid() + "\n" + d.LastMonth.StringTotalPaid() + "\n\n" + d.Month.String()
+}
+
+// Header is the one of the month info, with the expected and paid salary of the month.
+func (d Dashboard) Header() []string {
+	return append(d.Month.Header(), "expected", "paid")
+}
+
+func (d Dashboard) Rows() [][]string {
+	return [][]string{append(d.Month.Rows()[0], f2s(d.ThisMonth.ExpectedSalary), f2s(d.ThisMonth.Paid))}
+}
+
+type dashboardView struct {
+	ThisMonth salaryView    `json:"this_month" yaml:"this_month"`
+	LastMonth salaryView    `json:"last_month" yaml:"last_month"`
+	Month     monthInfoView `json:"month" yaml:"month"`
+}
+
+func (d Dashboard) View() interface{} {
+	return dashboardView{ThisMonth: d.ThisMonth.view(), LastMonth: d.LastMonth.view(), Month: d.Month.view()}
+}
@@ -1,10 +1,37 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+var (
+	ErrBadDate  = errors.New("use YYYY-MM-DD, MM-DD, today, yesterday, -2d or a weekday, e.g. fri")
+	ErrBadMonth = errors.New("use YYYY-MM, MM or a date")
+	ErrBadWeek  = errors.New("use YYYY-Www, Www or a date, e.g. 2024-W19")
+)
+
+// layoutDay and layoutMonth are the forms of dates and months in the views, the stable form of the types
+// in JSON, YAML and CSV that the printer prints in place of the types.
+const (
+	layoutDay   = "2006-01-02"
+	layoutMonth = "2006-01"
+)
+
+var (
+	relativeDay = regexp.MustCompile(`^-(\d+)([dw])$`)
+	isoWeek     = regexp.MustCompile(`^(?:(\d{4})-)?[wW](\d{1,2})$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
 type Dates []Date
 
 func (d Dates) String() string {
@@ -59,6 +86,137 @@ func ParseDate(layout, value string) (Date, error) {
 	return Date{t}, nil
 }
 
-func Today() Date {
+// Today is the current day, with the clock. It is a variable for tests to fix the day.
+var Today = func() Date {
 	return Date{time.Now()}
 }
+
+// ParseDay parses the day relative to now. It accepts YYYY-MM-DD; MM-DD in the nearest past year;
+// today and yesterday; days or weeks back, e.g. -2d or -1w; weekday names, e.g. fri or friday
+// for the latest Friday, today if it is Friday.
+func ParseDay(value string, now Date) (Date, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	today := Date{time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())}
+
+	switch value {
+	case "", "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if match := relativeDay.FindStringSubmatch(value); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return today, fmt.Errorf("%s: %w", value, ErrBadDate)
+		}
+
+		if match[2] == "w" {
+			n *= 7
+		}
+
+		return today.AddDate(0, 0, -n), nil
+	}
+
+	if len(value) >= 3 {
+		if weekday, ok := weekdays[value[:3]]; ok && strings.HasPrefix(strings.ToLower(weekday.String()), value) {
+			days := int(today.Weekday()-weekday+7) % 7
+
+			return today.AddDate(0, 0, -days), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return Date{t}, nil
+	}
+
+	if t, err := time.ParseInLocation("01-02", value, now.Location()); err == nil {
+		year := today.Year()
+		if t.Month() > today.Month() || t.Month() == today.Month() && t.Day() > today.Day() {
+			year--
+		}
+
+		// Feb 29 parses with the leap year zero, but would roll over to March in a common year
+		date := Date{time.Date(year, t.Month(), t.Day(), 0, 0, 0, 0, now.Location())}
+		if date.Month() != t.Month() || date.Day() != t.Day() {
+			return today, fmt.Errorf("%s: not in %d: %w", value, year, ErrBadDate)
+		}
+
+		return date, nil
+	}
+
+	return today, fmt.Errorf("%s: %w", value, ErrBadDate)
+}
+
+// ParseMonth parses the month relative to now. It accepts YYYY-MM, MM in the nearest past year,
+// or anything ParseDay does to take the month of that day. The first day of the month is returned.
+func ParseMonth(value string, now Date) (Date, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.ParseInLocation("2006-01", value, now.Location()); err == nil {
+		return Date{t}, nil
+	}
+
+	if t, err := time.ParseInLocation("01", value, now.Location()); err == nil {
+		month := Date{time.Date(now.Year(), t.Month(), 1, 0, 0, 0, 0, now.Location())}
+		if month.After(now.Time) {
+			month = month.AddDate(-1, 0, 0)
+		}
+
+		return month, nil
+	}
+
+	day, err := ParseDay(value, now)
+	if err != nil {
+		return day, fmt.Errorf("%s: %w", value, ErrBadMonth)
+	}
+
+	return Date{time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, now.Location())}, nil
+}
+
+// ParseWeek parses the week relative to now. It accepts YYYY-Www, Www in the nearest past year,
+// or anything ParseDay does to take the week of that day. Monday of the week is returned.
+func ParseWeek(value string, now Date) (Date, error) {
+	value = strings.TrimSpace(value)
+
+	match := isoWeek.FindStringSubmatch(value)
+	if match == nil {
+		day, err := ParseDay(value, now)
+		if err != nil {
+			return day, fmt.Errorf("%s: %w", value, ErrBadWeek)
+		}
+
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), nil
+	}
+
+	year := now.Year()
+	week, _ := strconv.Atoi(match[2])
+
+	if len(match[1]) > 0 {
+		year, _ = strconv.Atoi(match[1])
+	}
+
+	// January 4 is always in the first week
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, now.Location())
+	monday := Date{jan4.AddDate(0, 0, (week-1)*7-(int(jan4.Weekday())+6)%7)}
+
+	if len(match[1]) == 0 && monday.After(now.Time) {
+		return ParseWeek(strconv.Itoa(year-1)+"-"+value, now)
+	}
+
+	if y, w := monday.ISOWeek(); y != year || w != week {
+		return monday, fmt.Errorf("%s: %w", value, ErrBadWeek)
+	}
+
+	return monday, nil
+}
+
+// days formats the dates as YYYY-MM-DD, empty rather than nil for the stable output.
+func (d Dates) days() []string {
+	days := make([]string, 0, len(d))
+	for _, date := range d {
+		days = append(days, date.Format(layoutDay))
+	}
+
+	return days
+}
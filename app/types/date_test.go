@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -16,3 +17,97 @@ func TestDate_Equal(t *testing.T) {
 		So(p1.Equal(p2), ShouldBeTrue)
 	})
 }
+
+func TestParseDay(t *testing.T) {
+	Convey("ParseDay", t, func() {
+		now := types.Date{Time: time.Date(2022, time.January, 2, 15, 30, 0, 0, time.UTC)} // Sunday
+		day := func(year int, month time.Month, d int) types.Date {
+			return types.Date{Time: time.Date(year, month, d, 0, 0, 0, 0, time.UTC)}
+		}
+
+		cases := map[string]types.Date{
+			"":           day(2022, time.January, 2),
+			"today":      day(2022, time.January, 2),
+			"yesterday":  day(2022, time.January, 1),
+			"-2d":        day(2021, time.December, 31),
+			"-1w":        day(2021, time.December, 26),
+			"fri":        day(2021, time.December, 31),
+			"Friday":     day(2021, time.December, 31),
+			"sun":        day(2022, time.January, 2),
+			"mon":        day(2021, time.December, 27),
+			"12-31":      day(2021, time.December, 31),
+			"01-02":      day(2022, time.January, 2),
+			"2021-05-13": day(2021, time.May, 13),
+		}
+
+		for value, expected := range cases {
+			date, err := types.ParseDay(value, now)
+			So(err, ShouldBeNil)
+			So(date, ShouldResemble, expected)
+		}
+
+		for _, value := range []string{"fryday", "13-01", "-d", "2021-13-01", "02-29", "2021-02-29"} {
+			_, err := types.ParseDay(value, now)
+			So(errors.Is(err, types.ErrBadDate), ShouldBeTrue)
+		}
+
+		date, err := types.ParseDay("02-29", day(2024, time.March, 5))
+		So(err, ShouldBeNil)
+		So(date, ShouldResemble, day(2024, time.February, 29))
+	})
+}
+
+func TestParseMonth(t *testing.T) {
+	Convey("ParseMonth", t, func() {
+		now := types.Date{Time: time.Date(2022, time.January, 2, 15, 30, 0, 0, time.UTC)}
+		month := func(year int, m time.Month) types.Date {
+			return types.Date{Time: time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)}
+		}
+
+		cases := map[string]types.Date{
+			"2021-05":   month(2021, time.May),
+			"12":        month(2021, time.December),
+			"01":        month(2022, time.January),
+			"-2d":       month(2021, time.December),
+			"yesterday": month(2022, time.January),
+		}
+
+		for value, expected := range cases {
+			date, err := types.ParseMonth(value, now)
+			So(err, ShouldBeNil)
+			So(date, ShouldResemble, expected)
+		}
+
+		_, err := types.ParseMonth("2021-13", now)
+		So(errors.Is(err, types.ErrBadMonth), ShouldBeTrue)
+	})
+}
+
+func TestParseWeek(t *testing.T) {
+	Convey("ParseWeek", t, func() {
+		now := types.Date{Time: time.Date(2022, time.January, 5, 15, 30, 0, 0, time.UTC)}
+		day := func(year int, m time.Month, d int) types.Date {
+			return types.Date{Time: time.Date(year, m, d, 0, 0, 0, 0, time.UTC)}
+		}
+
+		cases := map[string]types.Date{
+			"2024-W19":   day(2024, time.May, 6),
+			"2020-w53":   day(2020, time.December, 28),
+			"W01":        day(2022, time.January, 3),
+			"w52":        day(2021, time.December, 27),
+			"today":      day(2022, time.January, 3),
+			"2022-01-02": day(2021, time.December, 27),
+		}
+
+		for value, expected := range cases {
+			date, err := types.ParseWeek(value, now)
+			So(err, ShouldBeNil)
+			So(date, ShouldResemble, expected)
+		}
+
+		for _, value := range []string{"2021-W53", "2021-W00", "week"} {
+			_, err := types.ParseWeek(value, now)
+			So(errors.Is(err, types.ErrBadWeek), ShouldBeTrue)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jwalton/gchalk"
+)
+
+// DayInfo is the timeline of the day: the entries in order, the lunch and the free slots of the workday between
+// them, the hours reported against the workday target, and where the next entry with a span would start.
+type DayInfo struct {
+	day     Date
+	history ReportEntries
+	workday Workday
+}
+
+func NewDayInfo(day Date, history ReportEntries, workday Workday) DayInfo {
+	entries := history.OfDay(day)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].StartTime.Before(entries[j].StartTime) })
+
+	return DayInfo{day: day, history: entries, workday: workday}
+}
+
+// Reported is the time reported for the day.
+func (d DayInfo) Reported() time.Duration {
+	return d.history.Duration()
+}
+
+// Left is the time left to report until the workday target.
+func (d DayInfo) Left() time.Duration {
+	if left := workdayHours - d.Reported(); left > 0 {
+		return left
+	}
+
+	return 0
+}
+
+// Next is the start of the next entry reported with a span, as AlignTimes places it; false if the day is full.
+func (d DayInfo) Next() (time.Time, bool) {
+	entries, err := ReportEntry{ReportDate: d.day, Span: 10 * time.Minute}.AlignTimes(d.history, d.workday)
+	if err != nil || len(entries) == 0 {
+		return time.Time{}, false
+	}
+
+	return entries[0].StartTime, true
+}
+
+func (d DayInfo) String() string {
+	builder := strings.Builder{}
+
+	builder.WriteString(gchalk.Bold(d.day.Format("Monday, January 2")) + "\n")
+
+	for _, slot := range d.timeline() {
+		fromTo := formatClock(slot.from) + "-" + formatClock(slot.to)
+
+		switch {
+		case slot.entry != nil:
+			builder.WriteString("  " + fromTo + " " + slot.entry.StringShort() + "\n")
+		case slot.lunch:
+			builder.WriteString(gchalk.Gray("  "+fromTo+" lunch") + "\n")
+		default:
+			builder.WriteString(gchalk.Yellow("  "+fromTo+" free, "+formatHours(slot.duration())) + "\n")
+		}
+	}
+
+	builder.WriteString("\nReported " + formatHours(d.Reported()) + " of " + formatHours(workdayHours))
+
+	if left := d.Left(); left > 0 {
+		builder.WriteString(", " + gchalk.Yellow(formatHours(left)+" left"))
+	}
+
+	if next, ok := d.Next(); ok {
+		builder.WriteString("\nNext report with a span starts at " + next.Format("15:04"))
+	}
+
+	return builder.String() + "\n"
+}
+
+type daySlot struct {
+	timeRange
+	entry *ReportEntry
+	lunch bool
+}
+
+// timeline is the entries, the lunch and the free slots in order. The lunch is the part of it no entry takes.
+// Free slots go from the day start until the workday target would be reached, or to the latest entry
+// if it ends later.
+func (d DayInfo) timeline() []daySlot {
+	slots := make([]daySlot, 0, 2*len(d.history)+2)
+
+	for i := range d.history {
+		entry := d.history[i]
+		slots = append(slots, daySlot{
+			timeRange: timeRange{from: sinceMidnight(entry.StartTime), to: sinceMidnight(entry.EndTime)},
+			entry:     &entry,
+		})
+	}
+
+	end := d.workday.Start + workdayHours
+
+	if d.workday.HasLunch() {
+		// the day without the lunch, so that the entries alone are busy
+		entries, _ := Workday{Start: d.workday.Start}.busy(d.history)
+
+		for _, r := range until(freeAfter(d.workday.LunchStart, entries), d.workday.LunchEnd) {
+			slots = append(slots, daySlot{timeRange: r, lunch: true})
+
+			if r.from < end {
+				end += r.duration()
+			}
+		}
+	}
+
+	busy, latest := d.workday.busy(d.history)
+	if latest > end {
+		end = latest
+	}
+
+	for _, r := range until(freeAfter(d.workday.Start, busy), end) {
+		slots = append(slots, daySlot{timeRange: r})
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].from < slots[j].from })
+
+	return slots
+}
+
+func formatClock(d time.Duration) string {
+	return clockAt(d).Format("15:04")
+}
+
+type daySlotView struct {
+	From  string     `json:"from" yaml:"from"`
+	To    string     `json:"to" yaml:"to"`
+	Kind  string     `json:"kind" yaml:"kind"`
+	Entry *entryView `json:"entry,omitempty" yaml:"entry,omitempty"`
+}
+
+type dayInfoView struct {
+	Date     string        `json:"date" yaml:"date"`
+	Reported float64       `json:"reported" yaml:"reported"`
+	Target   float64       `json:"target" yaml:"target"`
+	Left     float64       `json:"left" yaml:"left"`
+	Next     string        `json:"next,omitempty" yaml:"next,omitempty"`
+	Timeline []daySlotView `json:"timeline" yaml:"timeline"`
+}
+
+func (d DayInfo) view() dayInfoView {
+	v := dayInfoView{
+		Date:     d.day.Format(layoutDay),
+		Reported: d.Reported().Hours(),
+		Target:   workdayHours.Hours(),
+		Left:     d.Left().Hours(),
+		Timeline: []daySlotView{},
+	}
+
+	if next, ok := d.Next(); ok {
+		v.Next = next.Format("15:04")
+	}
+
+	for _, slot := range d.timeline() {
+		view := daySlotView{From: formatClock(slot.from), To: formatClock(slot.to), Kind: "free"}
+
+		switch {
+		case slot.entry != nil:
+			entry := slot.entry.view()
+			view.Kind, view.Entry = "entry", &entry
+		case slot.lunch:
+			view.Kind = "lunch"
+		}
+
+		v.Timeline = append(v.Timeline, view)
+	}
+
+	return v
+}
+
+func (d DayInfo) View() interface{} {
+	return d.view()
+}
+
+// Header is of the row per slot of the timeline; free slots and the lunch have only the times and the kind.
+func (d DayInfo) Header() []string {
+	return []string{"from", "to", "kind", "id", "project", "activity", "description", "hours"}
+}
+
+func (d DayInfo) Rows() [][]string {
+	v := d.view()
+	rows := make([][]string, 0, len(v.Timeline))
+
+	for _, slot := range v.Timeline {
+		row := []string{slot.From, slot.To, slot.Kind, "", "", "", "", ""}
+		if slot.Entry != nil {
+			row = append(row[:3], slot.Entry.ID, slot.Entry.Project, slot.Entry.Activity, slot.Entry.Description,
+				f2s(slot.Entry.Hours))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDayInfo(t *testing.T) {
+	Convey("DayInfo", t, func() {
+		day := types.Date{Time: time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC)}
+		entry := func(id, from, to string) types.ReportEntry {
+			start, _ := time.Parse("15:04", from)
+			end, _ := time.Parse("15:04", to)
+
+			return types.ReportEntry{ID: id, ReportDate: day, StartTime: start, EndTime: end, Span: end.Sub(start)}
+		}
+
+		workday, err := types.NewWorkday("09:00", "13:00-14:00", types.StackLatest)
+		So(err, ShouldBeNil)
+
+		tomorrow := entry("3", "09:00", "17:00")
+		tomorrow.ReportDate = day.AddDate(0, 0, 1)
+
+		history := types.ReportEntries{entry("2", "11:00", "12:00"), entry("1", "09:00", "10:00"), tomorrow}
+		info := types.NewDayInfo(day, history, workday)
+
+		So(info.Reported(), ShouldEqual, 2*time.Hour)
+		So(info.Left(), ShouldEqual, 6*time.Hour)
+
+		next, ok := info.Next()
+		So(ok, ShouldBeTrue)
+		So(next.Format("15:04"), ShouldEqual, "12:00")
+
+		timeline := func(info types.DayInfo) []struct{ From, To, Kind string } {
+			bts, err := json.Marshal(info.View())
+			So(err, ShouldBeNil)
+
+			var view struct {
+				Timeline []struct{ From, To, Kind string }
+			}
+			So(json.Unmarshal(bts, &view), ShouldBeNil)
+
+			return view.Timeline
+		}
+
+		So(timeline(info), ShouldResemble, []struct{ From, To, Kind string }{
+			{"09:00", "10:00", "entry"},
+			{"10:00", "11:00", "free"},
+			{"11:00", "12:00", "entry"},
+			{"12:00", "13:00", "free"},
+			{"13:00", "14:00", "lunch"},
+			{"14:00", "18:00", "free"},
+		})
+
+		// an entry over the lunch takes it, the rest of the lunch stays
+		info = types.NewDayInfo(day, append(history, entry("4", "13:00", "13:30")), workday)
+		So(timeline(info), ShouldResemble, []struct{ From, To, Kind string }{
+			{"09:00", "10:00", "entry"},
+			{"10:00", "11:00", "free"},
+			{"11:00", "12:00", "entry"},
+			{"12:00", "13:00", "free"},
+			{"13:00", "13:30", "entry"},
+			{"13:30", "14:00", "lunch"},
+			{"14:00", "17:30", "free"},
+		})
+	})
+}
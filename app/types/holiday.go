@@ -13,13 +13,20 @@ import (
 type Holidays []Holiday
 
 func (h Holidays) Holiday(day Date) bool {
+	_, ok := h.On(day)
+
+	return ok
+}
+
+// On returns the holiday on the day, if the day is one.
+func (h Holidays) On(day Date) (Holiday, bool) {
 	for _, holiday := range h {
 		if holiday.Date.Equal(day) {
-			return true
+			return holiday, true
 		}
 	}
 
-	return false
+	return Holiday{}, false
 }
 
 func (h Holidays) InMonth(day Date) Holidays {
@@ -93,3 +100,17 @@ func NewHolidaysFromHTMLNode(ctx context.Context, doc *html.Node) (Holidays, err
 
 	return holidays, nil
 }
+
+type holidayView struct {
+	Date string `json:"date" yaml:"date"`
+	Name string `json:"name" yaml:"name"`
+}
+
+func (hh Holidays) views() []holidayView {
+	views := make([]holidayView, 0, len(hh))
+	for _, holiday := range hh {
+		views = append(views, holidayView{Date: holiday.Date.Format(layoutDay), Name: holiday.Name})
+	}
+
+	return views
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jwalton/gchalk"
@@ -77,7 +78,9 @@ func (m MonthInfo) workingDays() []Date {
 	return days
 }
 
-func (m MonthInfo) needReporting() (Dates, Dates) {
+// Unreported returns working days of the month up to the moment with nothing reported,
+// skipping weekends, holidays and vacations.
+func (m MonthInfo) Unreported() Dates {
 	var need Dates
 
 	for _, day := range m.workingDays() {
@@ -88,6 +91,12 @@ func (m MonthInfo) needReporting() (Dates, Dates) {
 		need = append(need, day)
 	}
 
+	return need
+}
+
+func (m MonthInfo) needReporting() (Dates, Dates) {
+	need := m.Unreported()
+
 	deadIdx := 0
 	day := time.Now().Day()
 
@@ -103,3 +112,46 @@ func (m MonthInfo) needReporting() (Dates, Dates) {
 
 	return need[:deadIdx], need[deadIdx:]
 }
+
+type monthInfoView struct {
+	Month         string         `json:"month" yaml:"month"`
+	HoursInMonth  float64        `json:"hours_in_month" yaml:"hours_in_month"`
+	WorkingDays   float64        `json:"working_days" yaml:"working_days"`
+	ReportedHours float64        `json:"reported_hours" yaml:"reported_hours"`
+	ExpectedHours float64        `json:"expected_hours" yaml:"expected_hours"`
+	Missed        []string       `json:"missed" yaml:"missed"`
+	NeedReporting []string       `json:"need_reporting" yaml:"need_reporting"`
+	Holidays      []holidayView  `json:"holidays" yaml:"holidays"`
+	Vacations     []vacationView `json:"vacations" yaml:"vacations"`
+}
+
+func (m MonthInfo) view() monthInfoView {
+	dead, need := m.needReporting()
+
+	return monthInfoView{
+		Month:         m.moment.Format(layoutMonth),
+		HoursInMonth:  m.salary.WorkingDaysInMonth * 8,
+		WorkingDays:   m.salary.WorkingDaysInMonth,
+		ReportedHours: m.salary.HoursByCurrDay,
+		ExpectedHours: float64(len(m.workingDays()) * 8),
+		Missed:        dead.days(),
+		NeedReporting: need.days(),
+		Holidays:      m.holidays.InMonth(m.moment).views(),
+		Vacations:     m.vacations.InMonth(m.moment).views(),
+	}
+}
+
+func (m MonthInfo) Header() []string {
+	return []string{
+		"month", "hours_in_month", "working_days", "reported_hours", "expected_hours", "missed", "need_reporting",
+	}
+}
+
+func (m MonthInfo) Rows() [][]string {
+	v := m.view()
+
+	return [][]string{{
+		v.Month, f2s(v.HoursInMonth), f2s(v.WorkingDays), f2s(v.ReportedHours), f2s(v.ExpectedHours),
+		strings.Join(v.Missed, " "), strings.Join(v.NeedReporting, " "),
+	}}
+}
@@ -228,3 +228,69 @@ func (p Persons) Filter(f PersonsFilter) Persons {
 
 	return out
 }
+
+// personView has the birthday as MM-DD, as the year is not known.
+type personView struct {
+	ID             int    `json:"id" yaml:"id"`
+	Name           string `json:"name" yaml:"name"`
+	Team           string `json:"team" yaml:"team"`
+	Birthday       string `json:"birthday,omitempty" yaml:"birthday,omitempty"`
+	Status         string `json:"status,omitempty" yaml:"status,omitempty"`
+	Email          string `json:"email,omitempty" yaml:"email,omitempty"`
+	Skype          string `json:"skype,omitempty" yaml:"skype,omitempty"`
+	Grade          string `json:"grade,omitempty" yaml:"grade,omitempty"`
+	EnglishLevel   string `json:"english_level,omitempty" yaml:"english_level,omitempty"`
+	EnglishDetails string `json:"english_details,omitempty" yaml:"english_details,omitempty"`
+	URL            string `json:"url,omitempty" yaml:"url,omitempty"`
+	PhotoURL       string `json:"photo_url,omitempty" yaml:"photo_url,omitempty"`
+}
+
+func (p Person) view() personView {
+	view := personView{
+		ID: p.ID, Name: p.Name, Team: p.Team, Email: p.Email, Skype: p.Skype, Grade: p.Grade,
+		Status:       strings.TrimSpace(regexMultiSpace.ReplaceAllString(p.Status, " ")),
+		EnglishLevel: p.EnglishLevel, EnglishDetails: p.EnglishDetails, URL: p.URL, PhotoURL: p.PhotoURL,
+	}
+
+	if !p.Birthday.IsZero() {
+		view.Birthday = p.Birthday.Format("01-02")
+	}
+
+	return view
+}
+
+func (p Person) View() interface{} {
+	return p.view()
+}
+
+func (p Persons) View() interface{} {
+	views := make([]personView, 0, len(p))
+	for _, person := range p {
+		views = append(views, person.view())
+	}
+
+	return views
+}
+
+func (p Person) Header() []string {
+	return Persons{p}.Header()
+}
+
+func (p Person) Rows() [][]string {
+	return Persons{p}.Rows()
+}
+
+func (p Persons) Header() []string {
+	return []string{"id", "name", "team", "birthday", "email"}
+}
+
+func (p Persons) Rows() [][]string {
+	rows := make([][]string, 0, len(p))
+
+	for _, person := range p {
+		v := person.view()
+		rows = append(rows, []string{strconv.Itoa(v.ID), v.Name, v.Team, v.Birthday, v.Email})
+	}
+
+	return rows
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/kudrykv/go-vkpm/app/th"
@@ -18,11 +20,24 @@ var (
 	ErrProjNotFound  = errors.New("project not found")
 )
 
+// Match finds the project by the name. Projects are ranked by how well the name matches: exact,
+// prefix, start of a word, substring, and fuzzy when letters of the name go in the same order.
+// Only the best ranked are considered, and AmbiguousProjectError is returned if there are many.
 func (p Projects) Match(name string) (Project, error) {
-	var matched Projects
+	var (
+		best    matchRank
+		matched Projects
+	)
 
 	for _, project := range p {
-		if idx := strings.Index(strings.ToLower(project.Name), strings.ToLower(name)); idx >= 0 {
+		rank := rankMatch(project.Name, name)
+
+		switch {
+		case rank == rankNone || rank < best:
+			continue
+		case rank > best:
+			best, matched = rank, Projects{project}
+		default:
 			matched = append(matched, project)
 		}
 	}
@@ -32,12 +47,102 @@ func (p Projects) Match(name string) (Project, error) {
 	}
 
 	if len(matched) > 1 {
-		return Project{}, fmt.Errorf("found multiple %s: %w", matched, ErrTooPermissive)
+		return Project{}, &AmbiguousProjectError{Name: name, Candidates: matched}
 	}
 
 	return matched[0], nil
 }
 
+type matchRank int
+
+const (
+	rankNone matchRank = iota
+	rankFuzzy
+	rankSubstring
+	rankWord
+	rankPrefix
+	rankExact
+)
+
+func rankMatch(projectName, name string) matchRank {
+	projectName, name = strings.ToLower(projectName), strings.ToLower(name)
+
+	if projectName == name {
+		return rankExact
+	}
+
+	if strings.HasPrefix(projectName, name) {
+		return rankPrefix
+	}
+
+	idx := strings.Index(projectName, name)
+	if idx < 0 {
+		if isSubsequence(projectName, strings.ReplaceAll(name, " ", "")) {
+			return rankFuzzy
+		}
+
+		return rankNone
+	}
+
+	for ; idx >= 0; idx = nextIndex(projectName, name, idx) {
+		if r, _ := utf8.DecodeLastRuneInString(projectName[:idx]); !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return rankWord
+		}
+	}
+
+	return rankSubstring
+}
+
+func nextIndex(s, substr string, after int) int {
+	next := strings.Index(s[after+1:], substr)
+	if next < 0 {
+		return next
+	}
+
+	return after + 1 + next
+}
+
+func isSubsequence(s, sub string) bool {
+	for _, r := range sub {
+		idx := strings.IndexRune(s, r)
+		if idx < 0 {
+			return false
+		}
+
+		s = s[idx+utf8.RuneLen(r):]
+	}
+
+	return true
+}
+
+// AmbiguousProjectError lists the projects matched equally well by the name.
+type AmbiguousProjectError struct {
+	Name       string
+	Candidates Projects
+}
+
+func (e *AmbiguousProjectError) Error() string {
+	return fmt.Sprintf("%s matches %s: %v", e.Name, e.Candidates, ErrTooPermissive)
+}
+
+func (e *AmbiguousProjectError) Unwrap() error {
+	return ErrTooPermissive
+}
+
+// ProjectAliases maps short names to project names, e.g. k4s to Kube For Startups (internal).
+type ProjectAliases map[string]string
+
+// Resolve returns the project name for the alias, or the name itself if it is not an alias.
+func (a ProjectAliases) Resolve(name string) string {
+	for alias, projectName := range a {
+		if strings.EqualFold(alias, name) {
+			return projectName
+		}
+	}
+
+	return name
+}
+
 func (p Projects) String() string {
 	if len(p) == 0 {
 		return ""
@@ -87,3 +192,31 @@ func NewProjectsFromHTMLNode(ctx context.Context, doc *html.Node) (Projects, err
 
 	return projects, nil
 }
+
+type projectView struct {
+	ID   string `json:"id" yaml:"id"`
+	Name string `json:"name" yaml:"name"`
+}
+
+func (p Projects) View() interface{} {
+	views := make([]projectView, 0, len(p))
+	for _, project := range p {
+		views = append(views, projectView(project))
+	}
+
+	return views
+}
+
+func (p Projects) Header() []string {
+	return []string{"id", "name"}
+}
+
+func (p Projects) Rows() [][]string {
+	rows := make([][]string, 0, len(p))
+
+	for _, project := range p {
+		rows = append(rows, []string{project.ID, project.Name})
+	}
+
+	return rows
+}
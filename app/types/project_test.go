@@ -0,0 +1,65 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProjects_Match(t *testing.T) {
+	Convey("Match", t, func() {
+		egg := types.Project{ID: "1", Name: "Egg Inc."}
+		eggFarm := types.Project{ID: "2", Name: "Egg Farm"}
+		kube := types.Project{ID: "3", Name: "Kube For Startups (internal)"}
+		vegan := types.Project{ID: "4", Name: "Vegan Eggs"}
+		projects := types.Projects{egg, eggFarm, kube, vegan}
+
+		cases := map[string]types.Project{
+			"egg inc.": egg,     // exact
+			"egg i":    egg,     // prefix
+			"farm":     eggFarm, // start of a word
+			"ternal":   kube,    // substring
+			"kfs":      kube,    // fuzzy
+			"vgn egs":  vegan,   // fuzzy with spaces
+		}
+
+		for name, expected := range cases {
+			project, err := projects.Match(name)
+			So(err, ShouldBeNil)
+			So(project, ShouldResemble, expected)
+		}
+
+		Convey("ambiguous", func() {
+			_, err := projects.Match("egg")
+			So(errors.Is(err, types.ErrTooPermissive), ShouldBeTrue)
+
+			var ambiguous *types.AmbiguousProjectError
+			So(errors.As(err, &ambiguous), ShouldBeTrue)
+			So(ambiguous.Candidates, ShouldResemble, types.Projects{egg, eggFarm})
+		})
+
+		Convey("exact among longer names", func() {
+			// edit matches the project of the entry by its full name, which may start other names
+			project, err := types.Projects{{Name: "Kube For Startups"}, {Name: "Kube"}}.Match("kube")
+			So(err, ShouldBeNil)
+			So(project.Name, ShouldEqual, "Kube")
+		})
+
+		Convey("not found", func() {
+			_, err := projects.Match("zzz")
+			So(errors.Is(err, types.ErrProjNotFound), ShouldBeTrue)
+		})
+	})
+}
+
+func TestProjectAliases_Resolve(t *testing.T) {
+	Convey("Resolve", t, func() {
+		aliases := types.ProjectAliases{"k4s": "Kube For Startups (internal)"}
+
+		So(aliases.Resolve("K4s"), ShouldEqual, "Kube For Startups (internal)")
+		So(aliases.Resolve("egg"), ShouldEqual, "egg")
+		So(types.ProjectAliases(nil).Resolve("egg"), ShouldEqual, "egg")
+	})
+}
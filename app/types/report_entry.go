@@ -128,8 +128,11 @@ func (e ReportEntries) Reported(d Date) bool {
 
 func (e ReportEntries) FindLatestForToday(date Date) *ReportEntry {
 	sort.Slice(e, func(i, j int) bool {
-		return e[i].ReportDate.Add(e[i].EndTime.Sub(e[i].StartTime)).
-			After(e[j].ReportDate.Add(e[j].EndTime.Sub(e[j].StartTime)))
+		if !e[i].ReportDate.Equal(e[j].ReportDate) {
+			return e[i].ReportDate.After(e[j].ReportDate.Time)
+		}
+
+		return e[i].EndTime.Hour()*60+e[i].EndTime.Minute() > e[j].EndTime.Hour()*60+e[j].EndTime.Minute()
 	})
 
 	for _, entry := range e {
@@ -143,6 +146,31 @@ func (e ReportEntries) FindLatestForToday(date Date) *ReportEntry {
 	return nil
 }
 
+func (e ReportEntries) OfDay(date Date) ReportEntries {
+	var list ReportEntries
+
+	for _, entry := range e {
+		if entry.ReportDate.Equal(date) {
+			list = append(list, entry)
+		}
+	}
+
+	return list
+}
+
+// FindSame finds the entry with the same date, time range and content, e.g. the one just reported.
+func (e ReportEntries) FindSame(entry ReportEntry) *ReportEntry {
+	for _, current := range e {
+		if current.IsSame(entry) {
+			cp := current
+
+			return &cp
+		}
+	}
+
+	return nil
+}
+
 func (e ReportEntries) Overlaps(entry ReportEntry) ReportEntries {
 	var list ReportEntries
 
@@ -229,6 +257,43 @@ func (e ReportEntries) groupBy(cmp func(l, r Date) bool) []ReportEntries {
 	return groups
 }
 
+// EntriesFilter keeps the entries reported From To the days inclusive, of the project and the activity,
+// having Grep in the title or the description, and spanning at least MinSpan. Zero fields keep everything.
+type EntriesFilter struct {
+	From     Date
+	To       Date
+	Project  string
+	Activity string
+	Grep     string
+	MinSpan  time.Duration
+}
+
+// Filter returns the entries passing the filter. Names and the text are compared ignoring the case.
+func (e ReportEntries) Filter(f EntriesFilter) ReportEntries {
+	const layout = "2006-01-02"
+
+	grep := strings.ToLower(f.Grep)
+	out := make(ReportEntries, 0, len(e))
+
+	for _, entry := range e {
+		day := entry.ReportDate.Format(layout)
+
+		switch {
+		case !f.From.IsZero() && day < f.From.Format(layout),
+			!f.To.IsZero() && day > f.To.Format(layout),
+			len(f.Project) > 0 && !strings.EqualFold(entry.Project.Name, f.Project),
+			len(f.Activity) > 0 && !strings.EqualFold(entry.Activity, f.Activity),
+			len(grep) > 0 && !strings.Contains(strings.ToLower(entry.Name+"\n"+entry.Description), grep),
+			entry.Span < f.MinSpan:
+			continue
+		}
+
+		out = append(out, entry)
+	}
+
+	return out
+}
+
 type ReportEntry struct {
 	ID          string
 	PublishDate Date
@@ -271,8 +336,30 @@ var (
 	ErrNoTitle        = errors.New("no title")
 	ErrNoMessage      = errors.New("no message")
 	ErrNoRange        = errors.New("range must be set")
+	ErrRangeAndSpan   = errors.New("use only range or span")
 )
 
+// TestTime checks that the entry has either a span or a time range, rounded to 10 minutes.
+func (e ReportEntry) TestTime() error {
+	if e.Span != e.Span.Round(10*time.Minute) {
+		return fmt.Errorf("span %v: %w", e.Span, ErrTimeNotRounded)
+	}
+
+	if err := e.TestBrokenRange(); err != nil {
+		return fmt.Errorf("broken range: %w", err)
+	}
+
+	if e.IsSpanAndRangePresent() {
+		return fmt.Errorf("both range and span defined: %w", ErrRangeAndSpan)
+	}
+
+	if e.IsSpanAndRangeAbsent() {
+		return fmt.Errorf("no range nor span defined: %w", ErrNoAnyTime)
+	}
+
+	return nil
+}
+
 func (e ReportEntry) TestBrokenRange() error {
 	if e.IsEmptyRange() {
 		return nil
@@ -349,9 +436,10 @@ func (e ReportEntry) SetActivity(short string) (ReportEntry, error) {
 	return e, fmt.Errorf("%v: %w", short, ErrBadActivity)
 }
 
-func (e ReportEntry) UpdateProjectName(available Projects) (ReportEntry, error) {
+// UpdateProjectName resolves the alias, if any, and matches the project among available ones.
+func (e ReportEntry) UpdateProjectName(available Projects, aliases ProjectAliases) (ReportEntry, error) {
 	var err error
-	if e.Project, err = available.Match(e.Project.Name); err != nil {
+	if e.Project, err = available.Match(aliases.Resolve(e.Project.Name)); err != nil {
 		return e, fmt.Errorf("match: %w", err)
 	}
 
@@ -362,37 +450,48 @@ func (e ReportEntry) UpdateProjectName(available Projects) (ReportEntry, error)
 	return e, nil
 }
 
-func (e ReportEntry) AlignTimes(history ReportEntries) (ReportEntry, error) {
+// AlignTimes places the entry reported with a span within the day according to the workday rules.
+// The span is split into several entries if it does not fit between the entries and the lunch.
+func (e ReportEntry) AlignTimes(history ReportEntries, day Workday) (ReportEntries, error) {
 	if e.ReportDate.IsZero() {
-		return e, fmt.Errorf("zero: %w", ErrNoReportDate)
+		return nil, fmt.Errorf("zero: %w", ErrNoReportDate)
 	}
 
 	if e.IsSpanAndRangeAbsent() {
-		return e, fmt.Errorf("no time: %w", ErrNoAnyTime)
+		return nil, fmt.Errorf("no time: %w", ErrNoAnyTime)
 	}
 
 	if e.Span == 0 {
 		e.Span = e.EndTime.Sub(e.StartTime)
 	}
 
-	if e.IsEmptyRange() {
-		e.StartTime = time.Date(0, 0, 0, 9, 0, 0, 0, time.UTC)
-		if entry := history.FindLatestForToday(e.ReportDate); entry != nil {
-			e.StartTime = entry.EndTime
+	if !e.IsEmptyRange() {
+		if entries := history.Overlaps(e); len(entries) > 0 {
+			return nil, fmt.Errorf("entries: %w", ErrOverlaps)
 		}
 
-		e.EndTime = e.StartTime.Add(e.Span)
+		return ReportEntries{e}, nil
+	}
 
-		if e.StartTime.Day() != e.EndTime.Day() {
-			return e, fmt.Errorf("overflow: %w", ErrTimeOverflow)
-		}
+	ranges, err := day.place(e.Span, history.OfDay(e.ReportDate))
+	if err != nil {
+		return nil, fmt.Errorf("place: %w", err)
 	}
 
-	if entries := history.Overlaps(e); len(entries) > 0 {
-		return e, fmt.Errorf("entries: %w", ErrOverlaps)
+	entries := make(ReportEntries, 0, len(ranges))
+
+	for _, r := range ranges {
+		entry := e
+		entry.StartTime, entry.EndTime, entry.Span = clockAt(r.from), clockAt(r.to), r.duration()
+
+		if overlaps := history.Overlaps(entry); len(overlaps) > 0 {
+			return nil, fmt.Errorf("entries: %w", ErrOverlaps)
+		}
+
+		entries = append(entries, entry)
 	}
 
-	return e, nil
+	return entries, nil
 }
 
 func (e ReportEntry) String() string {
@@ -492,7 +591,8 @@ func (e ReportEntry) IsSame(o ReportEntry) bool {
 }
 
 func (e ReportEntry) StringShort() string {
-	return fmt.Sprintf("%15s", gchalk.Green(strings.ReplaceAll(e.Span.String(), "0s", ""))) + " " +
+	return gchalk.Gray("#"+e.ID) + " " +
+		fmt.Sprintf("%15s", gchalk.Green(strings.ReplaceAll(e.Span.String(), "0s", ""))) + " " +
 		gchalk.Magenta(e.Project.Name) + " " +
 		strings.ReplaceAll(e.Description, "\n", "/")
 }
@@ -582,3 +682,79 @@ func NewReportEntriesFromHTMLNode(ctx context.Context, doc *html.Node) (ReportEn
 
 	return entries, nil
 }
+
+type entryView struct {
+	ID          string  `json:"id" yaml:"id"`
+	Date        string  `json:"date" yaml:"date"`
+	ProjectID   string  `json:"project_id" yaml:"project_id"`
+	Project     string  `json:"project" yaml:"project"`
+	Activity    string  `json:"activity" yaml:"activity"`
+	Title       string  `json:"title" yaml:"title"`
+	Description string  `json:"description" yaml:"description"`
+	Status      int     `json:"status" yaml:"status"`
+	From        string  `json:"from" yaml:"from"`
+	To          string  `json:"to" yaml:"to"`
+	Hours       float64 `json:"hours" yaml:"hours"`
+}
+
+func (e ReportEntry) view() entryView {
+	return entryView{
+		ID:          e.ID,
+		Date:        e.ReportDate.Format(layoutDay),
+		ProjectID:   e.Project.ID,
+		Project:     e.Project.Name,
+		Activity:    e.Activity,
+		Title:       e.Name,
+		Description: e.Description,
+		Status:      e.Status,
+		From:        e.StartTime.Format("15:04"),
+		To:          e.EndTime.Format("15:04"),
+		Hours:       e.hours().Hours(),
+	}
+}
+
+// hours is the span, or the range if the span is not set.
+func (e ReportEntry) hours() time.Duration {
+	if e.Span > 0 {
+		return e.Span
+	}
+
+	return e.EndTime.Sub(e.StartTime)
+}
+
+func (e ReportEntry) View() interface{} {
+	return e.view()
+}
+
+func (e ReportEntries) View() interface{} {
+	return e.views()
+}
+
+func (e ReportEntries) views() []entryView {
+	views := make([]entryView, 0, len(e))
+	for _, entry := range e {
+		views = append(views, entry.view())
+	}
+
+	return views
+}
+
+func (e ReportEntries) Header() []string {
+	return []string{
+		"id", "date", "project_id", "project", "activity", "title", "description", "status", "from", "to", "hours",
+	}
+}
+
+func (e ReportEntries) Rows() [][]string {
+	rows := make([][]string, 0, len(e))
+
+	for _, entry := range e {
+		v := entry.view()
+		rows = append(rows, []string{
+			v.ID, v.Date, v.ProjectID, v.Project, v.Activity, v.Title, v.Description,
+			strconv.Itoa(v.Status), v.From, v.To, f2s(v.Hours),
+		})
+	}
+
+	return rows
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
 )
 
 func TestReportEntry_String(t *testing.T) {
@@ -25,3 +26,51 @@ func TestReportEntry_String(t *testing.T) {
 
 	fmt.Println(re.String())
 }
+
+func TestReportEntry_StringShort(t *testing.T) {
+	Convey("StringShort", t, func() {
+		entry := types.ReportEntry{ID: "123", Project: types.Project{Name: "Egg Inc."}, Span: 90 * time.Minute}
+
+		// the ID goes first, to be taken for report edit and report rm
+		So(entry.StringShort(), ShouldStartWith, "#123")
+		So(entry.StringShort(), ShouldContainSubstring, "1h30m")
+	})
+}
+
+func TestReportEntries_Filter(t *testing.T) {
+	Convey("Filter", t, func() {
+		day := func(month time.Month, d int) types.Date {
+			return types.Date{Time: time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)}
+		}
+		egg := types.Project{Name: "Egg Inc."}
+		entries := types.ReportEntries{
+			{ID: "1", ReportDate: day(time.March, 29), Project: egg, Activity: types.ActivityDevelopment, Span: time.Hour},
+			{ID: "2", ReportDate: day(time.April, 1), Project: egg, Activity: types.ActivityManagement, Name: "Sync"},
+			{ID: "3", ReportDate: day(time.May, 2), Project: types.Project{Name: "K4S"}, Description: "sync up"},
+			{ID: "4", ReportDate: day(time.June, 30), Project: egg, Span: 2 * time.Hour, Description: "login"},
+		}
+		ids := func(entries types.ReportEntries) []string {
+			out := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				out = append(out, entry.ID)
+			}
+
+			return out
+		}
+
+		So(ids(entries.Filter(types.EntriesFilter{})), ShouldResemble, []string{"1", "2", "3", "4"})
+
+		q2 := types.EntriesFilter{
+			From: day(time.April, 1),
+			To:   types.Date{Time: time.Date(2024, time.June, 30, 0, 0, 0, 0, time.Local)},
+		}
+		So(ids(entries.Filter(q2)), ShouldResemble, []string{"2", "3", "4"})
+
+		q2.Project = "egg inc."
+		So(ids(entries.Filter(q2)), ShouldResemble, []string{"2", "4"})
+
+		So(ids(entries.Filter(types.EntriesFilter{Grep: "SYNC"})), ShouldResemble, []string{"2", "3"})
+		So(ids(entries.Filter(types.EntriesFilter{Activity: types.ActivityManagement})), ShouldResemble, []string{"2"})
+		So(ids(entries.Filter(types.EntriesFilter{MinSpan: time.Hour})), ShouldResemble, []string{"1", "4"})
+	})
+}
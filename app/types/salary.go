@@ -260,3 +260,39 @@ func getIntFromDirtyString(str string) (int, error) {
 
 	return atoi, nil
 }
+
+type salaryView struct {
+	Month               string  `json:"month" yaml:"month"`
+	RatePerHour         float64 `json:"rate_per_hour" yaml:"rate_per_hour"`
+	Rate                float64 `json:"rate" yaml:"rate"`
+	WorkingDays         float64 `json:"working_days" yaml:"working_days"`
+	HoursByCurrentDay   float64 `json:"hours_by_current_day" yaml:"hours_by_current_day"`
+	DollarsByCurrentDay float64 `json:"dollars_by_current_day" yaml:"dollars_by_current_day"`
+	Expected            float64 `json:"expected" yaml:"expected"`
+	VacationHours       float64 `json:"vacation_hours" yaml:"vacation_hours"`
+	VacationDollars     float64 `json:"vacation_dollars" yaml:"vacation_dollars"`
+	OvertimeHours       float64 `json:"overtime_hours" yaml:"overtime_hours"`
+	OvertimeDollars     float64 `json:"overtime_dollars" yaml:"overtime_dollars"`
+	Bonus               float64 `json:"bonus" yaml:"bonus"`
+	Total               float64 `json:"total" yaml:"total"`
+	Paid                float64 `json:"paid" yaml:"paid"`
+}
+
+func (s Salary) view() salaryView {
+	return salaryView{
+		Month:               time.Date(s.Year, s.Month, 1, 0, 0, 0, 0, time.UTC).Format(layoutMonth),
+		RatePerHour:         s.RatePerHour,
+		Rate:                s.Rate,
+		WorkingDays:         s.WorkingDaysInMonth,
+		HoursByCurrentDay:   s.HoursByCurrDay,
+		DollarsByCurrentDay: s.DollarsByCurrDay,
+		Expected:            s.ExpectedSalary,
+		VacationHours:       s.VacationHours,
+		VacationDollars:     s.VacationDollars,
+		OvertimeHours:       s.OvertimeHours,
+		OvertimeDollars:     s.OvertimeDollars,
+		Bonus:               s.BonusDollars,
+		Total:               s.Total,
+		Paid:                s.Paid,
+	}
+}
@@ -16,6 +16,27 @@ type StatSalaryHistory struct {
 	Salaries   Salaries
 }
 
+// StatMonth is the salary and the entries of the month.
+type StatMonth struct {
+	Year    int
+	Month   time.Month
+	Salary  Salary
+	History ReportEntries
+}
+
+// Months are the months of the stat in order, including those with nothing reported.
+func (s StatSalaryHistory) Months() []StatMonth {
+	months := make([]StatMonth, 0, s.EndMonth-s.StartMonth+1)
+
+	for i := s.StartMonth; i <= s.EndMonth; i++ {
+		months = append(months, StatMonth{
+			Year: s.Year, Month: i, Salary: s.Salaries.At(s.Year, i), History: s.Histories.At(s.Year, i),
+		})
+	}
+
+	return months
+}
+
 func (s StatSalaryHistory) String() string {
 	b := strings.Builder{}
 
@@ -44,3 +65,76 @@ func (s StatSalaryHistory) String() string {
 
 	return b.String()
 }
+
+type projectHoursView struct {
+	Project string  `json:"project" yaml:"project"`
+	Hours   float64 `json:"hours" yaml:"hours"`
+}
+
+type statMonthView struct {
+	Month    string             `json:"month" yaml:"month"`
+	Expected float64            `json:"expected" yaml:"expected"`
+	Paid     float64            `json:"paid" yaml:"paid"`
+	Hours    float64            `json:"hours" yaml:"hours"`
+	Projects []projectHoursView `json:"projects" yaml:"projects"`
+}
+
+type statView struct {
+	Year     int             `json:"year" yaml:"year"`
+	Expected float64         `json:"expected" yaml:"expected"`
+	Paid     float64         `json:"paid" yaml:"paid"`
+	Months   []statMonthView `json:"months" yaml:"months"`
+}
+
+func (s StatSalaryHistory) view() statView {
+	v := statView{Year: s.Year, Expected: s.Salaries.Expected(), Paid: s.Salaries.Paid()}
+
+	for i := s.StartMonth; i <= s.EndMonth; i++ {
+		history := s.Histories.At(s.Year, i)
+		salary := s.Salaries.At(s.Year, i)
+		month := statMonthView{
+			Month:    time.Date(s.Year, i, 1, 0, 0, 0, 0, time.UTC).Format(layoutMonth),
+			Expected: salary.ExpectedSalary,
+			Paid:     salary.Paid,
+			Hours:    history.Duration().Hours(),
+			Projects: []projectHoursView{},
+		}
+
+		for _, ph := range history.ProjectHours() {
+			month.Projects = append(month.Projects, projectHoursView{
+				Project: ph.Project.Name, Hours: ph.Duration.Hours(),
+			})
+		}
+
+		v.Months = append(v.Months, month)
+	}
+
+	return v
+}
+
+func (s StatSalaryHistory) View() interface{} {
+	return s.view()
+}
+
+// Header is of the row per month, projects listed as "name: hours" separated by semicolons.
+func (s StatSalaryHistory) Header() []string {
+	return []string{"month", "expected", "paid", "hours", "projects"}
+}
+
+func (s StatSalaryHistory) Rows() [][]string {
+	v := s.view()
+	rows := make([][]string, 0, len(v.Months))
+
+	for _, month := range v.Months {
+		projects := make([]string, 0, len(month.Projects))
+		for _, ph := range month.Projects {
+			projects = append(projects, ph.Project+": "+f2s(ph.Hours))
+		}
+
+		rows = append(rows, []string{
+			month.Month, f2s(month.Expected), f2s(month.Paid), f2s(month.Hours), strings.Join(projects, "; "),
+		})
+	}
+
+	return rows
+}
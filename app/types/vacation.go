@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -170,3 +171,80 @@ func NewVacationsFromHTMLNode(doc *html.Node) (int, Vacations, error) {
 
 	return int(f), vacations, nil
 }
+
+// VacationsBalance is the vacations of the year with the paid days left.
+type VacationsBalance struct {
+	PaidDaysLeft int
+	Vacations    Vacations
+}
+
+func (b VacationsBalance) String() string {
+	return strconv.Itoa(b.PaidDaysLeft) + " day(s) of paid vacations left\n\n" + b.Vacations.String()
+}
+
+func (b VacationsBalance) Header() []string {
+	return b.Vacations.Header()
+}
+
+func (b VacationsBalance) Rows() [][]string {
+	return b.Vacations.Rows()
+}
+
+type vacationView struct {
+	ID     string  `json:"id" yaml:"id"`
+	Type   string  `json:"type" yaml:"type"`
+	Status string  `json:"status" yaml:"status"`
+	Paid   bool    `json:"paid" yaml:"paid"`
+	Start  string  `json:"start" yaml:"start"`
+	End    string  `json:"end" yaml:"end"`
+	Days   float64 `json:"days" yaml:"days"`
+	Note   string  `json:"note" yaml:"note"`
+}
+
+func (v Vacation) view() vacationView {
+	view := vacationView{
+		ID: v.ID, Type: v.Type, Status: v.Status, Paid: v.Paid,
+		Start: v.StartDate.Format(layoutDay), Days: v.Span.Hours() / 24, Note: v.Note,
+	}
+
+	if !v.EndDate.IsZero() {
+		view.End = v.EndDate.Format(layoutDay)
+	}
+
+	return view
+}
+
+func (vv Vacations) views() []vacationView {
+	views := make([]vacationView, 0, len(vv))
+	for _, vacation := range vv {
+		views = append(views, vacation.view())
+	}
+
+	return views
+}
+
+func (vv Vacations) Header() []string {
+	return []string{"id", "type", "status", "paid", "start", "end", "days", "note"}
+}
+
+func (vv Vacations) Rows() [][]string {
+	rows := make([][]string, 0, len(vv))
+
+	for _, vacation := range vv {
+		v := vacation.view()
+		rows = append(rows, []string{
+			v.ID, v.Type, v.Status, strconv.FormatBool(v.Paid), v.Start, v.End, f2s(v.Days), v.Note,
+		})
+	}
+
+	return rows
+}
+
+type vacationsBalanceView struct {
+	PaidDaysLeft int            `json:"paid_days_left" yaml:"paid_days_left"`
+	Vacations    []vacationView `json:"vacations" yaml:"vacations"`
+}
+
+func (b VacationsBalance) View() interface{} {
+	return vacationsBalanceView{PaidDaysLeft: b.PaidDaysLeft, Vacations: b.Vacations.views()}
+}
@@ -0,0 +1,276 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jwalton/gchalk"
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	workdayHours = 8 * time.Hour
+
+	offHoliday  = "holiday"
+	offVacation = "vacation"
+)
+
+// Week is the timesheet of the week: hours by the project and the day, against the hours expected
+// on working days, that is, weekdays except holidays and vacations.
+type Week struct {
+	monday    Date
+	today     Date
+	history   ReportEntries
+	vacations Vacations
+	holidays  Holidays
+}
+
+// NewWeek makes the week starting on the Monday. The history has to cover every day of it,
+// that is, both months for the week across them; entries outside the week are dropped.
+func NewWeek(monday Date, history ReportEntries, vacations Vacations, holidays Holidays) Week {
+	return Week{
+		monday:    monday,
+		today:     Today(),
+		history:   history.Filter(EntriesFilter{From: monday, To: monday.AddDate(0, 0, 6)}),
+		vacations: vacations,
+		holidays:  holidays,
+	}
+}
+
+// Expected is the hours expected in the working days of the whole week.
+func (w Week) Expected() time.Duration {
+	var expected time.Duration
+
+	for _, day := range w.days() {
+		if w.working(day) {
+			expected += workdayHours
+		}
+	}
+
+	return expected
+}
+
+// Gaps are the working days up to today with less than a workday reported.
+func (w Week) Gaps() Dates {
+	var gaps Dates
+
+	for _, day := range w.days() {
+		if w.gap(day) {
+			gaps = append(gaps, day)
+		}
+	}
+
+	return gaps
+}
+
+func (w Week) String() string {
+	year, week := w.monday.ISOWeek()
+	builder := strings.Builder{}
+
+	builder.WriteString(gchalk.Bold("W"+strconv.Itoa(week)) + " of " + strconv.Itoa(year) + ", " +
+		w.monday.Format("Jan 02") + " - " + w.monday.AddDate(0, 0, 6).Format("Jan 02") + "\n")
+
+	days := w.columns()
+	header := []string{"Project"}
+	footer := []string{"Total"}
+
+	for _, day := range days {
+		header = append(header, day.Format("Mon 02"))
+
+		reported := w.history.OfDay(day).Duration()
+
+		switch off := w.off(day); {
+		case len(off) > 0 && reported == 0:
+			footer = append(footer, gchalk.Gray(off))
+		case w.gap(day):
+			footer = append(footer, gchalk.Yellow(formatHours(reported)))
+		default:
+			footer = append(footer, formatHours(reported))
+		}
+	}
+
+	total := formatHours(w.history.Duration()) + " / " + formatHours(w.Expected())
+	if w.history.Duration() >= w.Expected() {
+		total = gchalk.Green(total)
+	}
+
+	table := tablewriter.NewWriter(&builder)
+	table.SetAutoFormatHeaders(false)
+	table.SetHeader(append(header, "Total"))
+	table.SetFooter(append(footer, total))
+
+	alignment := []int{tablewriter.ALIGN_LEFT}
+	for range header {
+		alignment = append(alignment, tablewriter.ALIGN_RIGHT)
+	}
+
+	table.SetColumnAlignment(alignment)
+
+	for _, ph := range w.history.ProjectHours() {
+		row := []string{ph.Project.Name}
+
+		for _, day := range days {
+			if hours := w.projectDay(ph.Project, day); hours > 0 {
+				row = append(row, formatHours(hours))
+			} else {
+				row = append(row, "")
+			}
+		}
+
+		table.Append(append(row, formatHours(ph.Duration)))
+	}
+
+	table.Render()
+
+	if gaps := w.Gaps(); len(gaps) > 0 {
+		builder.WriteString(gchalk.Yellow("Less than "+formatHours(workdayHours)+" reported for "+gaps.String()) + "\n")
+	}
+
+	return builder.String()
+}
+
+func (w Week) days() Dates {
+	days := make(Dates, 0, 7)
+	for i := 0; i < 7; i++ {
+		days = append(days, w.monday.AddDate(0, 0, i))
+	}
+
+	return days
+}
+
+// columns are the weekdays, and the weekend days if anything is reported on them.
+func (w Week) columns() Dates {
+	var days Dates
+
+	for _, day := range w.days() {
+		if day.IsWeekend() && len(w.history.OfDay(day)) == 0 {
+			continue
+		}
+
+		days = append(days, day)
+	}
+
+	return days
+}
+
+func (w Week) off(day Date) string {
+	switch {
+	case w.holidays.Holiday(day):
+		return offHoliday
+	case w.vacations.Vacated(day):
+		return offVacation
+	}
+
+	return ""
+}
+
+func (w Week) working(day Date) bool {
+	return !day.IsWeekend() && len(w.off(day)) == 0
+}
+
+func (w Week) gap(day Date) bool {
+	return w.working(day) && !day.After(w.today.Time) && w.history.OfDay(day).Duration() < workdayHours
+}
+
+func (w Week) projectDay(project Project, day Date) time.Duration {
+	var duration time.Duration
+
+	for _, entry := range w.history.OfDay(day) {
+		if entry.Project.Name == project.Name {
+			duration += entry.Span
+		}
+	}
+
+	return duration
+}
+
+// formatHours formats the duration as hours and minutes, e.g., 7:30.
+func formatHours(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+type weekDayView struct {
+	Date     string  `json:"date" yaml:"date"`
+	Reported float64 `json:"reported" yaml:"reported"`
+	Off      string  `json:"off,omitempty" yaml:"off,omitempty"`
+	Gap      bool    `json:"gap" yaml:"gap"`
+}
+
+type weekProjectView struct {
+	Project string    `json:"project" yaml:"project"`
+	Days    []float64 `json:"days" yaml:"days"`
+	Total   float64   `json:"total" yaml:"total"`
+}
+
+type weekView struct {
+	Week     string            `json:"week" yaml:"week"`
+	Expected float64           `json:"expected" yaml:"expected"`
+	Reported float64           `json:"reported" yaml:"reported"`
+	Days     []weekDayView     `json:"days" yaml:"days"`
+	Projects []weekProjectView `json:"projects" yaml:"projects"`
+}
+
+func (w Week) view() weekView {
+	year, week := w.monday.ISOWeek()
+	v := weekView{
+		Week:     fmt.Sprintf("%d-W%02d", year, week),
+		Expected: w.Expected().Hours(),
+		Reported: w.history.Duration().Hours(),
+		Projects: []weekProjectView{},
+	}
+
+	for _, day := range w.days() {
+		v.Days = append(v.Days, weekDayView{
+			Date: day.Format(layoutDay), Reported: w.history.OfDay(day).Duration().Hours(), Off: w.off(day), Gap: w.gap(day),
+		})
+	}
+
+	for _, ph := range w.history.ProjectHours() {
+		project := weekProjectView{Project: ph.Project.Name, Total: ph.Duration.Hours()}
+
+		for _, day := range w.days() {
+			project.Days = append(project.Days, w.projectDay(ph.Project, day).Hours())
+		}
+
+		v.Projects = append(v.Projects, project)
+	}
+
+	return v
+}
+
+func (w Week) View() interface{} {
+	return w.view()
+}
+
+// Header is the project, the days of the week from Monday and the total; the last row is the total of the days.
+func (w Week) Header() []string {
+	header := []string{"project"}
+	for _, day := range w.days() {
+		header = append(header, day.Format(layoutDay))
+	}
+
+	return append(header, "total")
+}
+
+func (w Week) Rows() [][]string {
+	v := w.view()
+	rows := make([][]string, 0, len(v.Projects)+1)
+
+	for _, project := range v.Projects {
+		row := []string{project.Project}
+		for _, hours := range project.Days {
+			row = append(row, f2s(hours))
+		}
+
+		rows = append(rows, append(row, f2s(project.Total)))
+	}
+
+	total := []string{"total"}
+	for _, day := range v.Days {
+		total = append(total, f2s(day.Reported))
+	}
+
+	return append(rows, append(total, f2s(v.Reported)))
+}
@@ -0,0 +1,52 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWeek(t *testing.T) {
+	Convey("Week", t, func() {
+		day := func(d int) types.Date {
+			return types.Date{Time: time.Date(2024, time.May, d, 0, 0, 0, 0, time.UTC)}
+		}
+		entry := func(d int, project string, span time.Duration) types.ReportEntry {
+			return types.ReportEntry{ReportDate: day(d), Project: types.Project{Name: project}, Span: span}
+		}
+
+		history := types.ReportEntries{
+			entry(3, "Egg Inc.", 8*time.Hour),
+			entry(6, "Egg Inc.", 6*time.Hour),
+			entry(6, "K4S", 2*time.Hour),
+			entry(7, "Egg Inc.", 5*time.Hour+30*time.Minute),
+			entry(11, "K4S", time.Hour),
+		}
+		vacations := types.Vacations{{StartDate: day(10)}}
+		holidays := types.Holidays{{Name: "Victory Day", Date: day(9)}}
+
+		week := types.NewWeek(day(6), history, vacations, holidays)
+
+		So(week.Expected(), ShouldEqual, 24*time.Hour)
+		So(week.Gaps(), ShouldResemble, types.Dates{day(7), day(8)})
+
+		text := week.String()
+		So(text, ShouldContainSubstring, "W19")
+		So(text, ShouldContainSubstring, "Sat 11")
+		So(text, ShouldNotContainSubstring, "Sun 12")
+		So(text, ShouldContainSubstring, "14:30 / 24:00")
+		So(text, ShouldContainSubstring, "holiday")
+
+		bts, err := json.Marshal(week.View())
+		So(err, ShouldBeNil)
+		So(string(bts), ShouldContainSubstring, `"week":"2024-W19","expected":24,"reported":14.5`)
+		So(string(bts), ShouldContainSubstring, `{"project":"Egg Inc.","days":[6,5.5,0,0,0,0,0],"total":11.5}`)
+
+		So(week.Rows()[2], ShouldResemble, []string{
+			"total", "8.00", "5.50", "0.00", "0.00", "0.00", "1.00", "0.00", "14.50",
+		})
+	})
+}
@@ -0,0 +1,233 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	StackLatest   = "latest"
+	StackFirstGap = "first-gap"
+	StackFill     = "fill"
+)
+
+var (
+	ErrBadStacking = errors.New("unknown stacking, use latest, first-gap or fill")
+	ErrBadLunch    = errors.New("lunch must be a range, e.g. 13:00-14:00")
+)
+
+// Workday tells where entries reported with a span are placed within the day.
+// Times are counted from midnight.
+type Workday struct {
+	Start      time.Duration
+	LunchStart time.Duration
+	LunchEnd   time.Duration
+	Stacking   string
+}
+
+func DefaultWorkday() Workday {
+	return Workday{Start: 9 * time.Hour, Stacking: StackLatest}
+}
+
+// NewWorkday parses the day start (15:04), the lunch range (15:04-15:04) and the stacking mode.
+// Empty values are left at defaults: day starts at 09:00, no lunch, entries stack after the latest one.
+func NewWorkday(start, lunch, stacking string) (Workday, error) {
+	var (
+		day = DefaultWorkday()
+		err error
+	)
+
+	if len(start) > 0 {
+		if day.Start, err = parseClock(start); err != nil {
+			return day, fmt.Errorf("start: %w", err)
+		}
+	}
+
+	if len(lunch) > 0 {
+		from, to, ok := strings.Cut(lunch, "-")
+		if !ok {
+			return day, fmt.Errorf("%s: %w", lunch, ErrBadLunch)
+		}
+
+		if day.LunchStart, err = parseClock(strings.TrimSpace(from)); err != nil {
+			return day, fmt.Errorf("lunch start: %w", err)
+		}
+
+		if day.LunchEnd, err = parseClock(strings.TrimSpace(to)); err != nil {
+			return day, fmt.Errorf("lunch end: %w", err)
+		}
+
+		if day.LunchStart >= day.LunchEnd {
+			return day, fmt.Errorf("%s: %w", lunch, ErrStartLargerEnd)
+		}
+	}
+
+	if len(stacking) > 0 {
+		day.Stacking = stacking
+	}
+
+	switch day.Stacking {
+	case StackLatest, StackFirstGap, StackFill:
+	default:
+		return day, fmt.Errorf("%s: %w", day.Stacking, ErrBadStacking)
+	}
+
+	return day, nil
+}
+
+func (w Workday) HasLunch() bool {
+	return w.LunchEnd > w.LunchStart
+}
+
+// place finds where the span goes in the day with the given entries, splitting it around the lunch if needed.
+// Fill stacking splits the span over the earliest free gaps of the day.
+func (w Workday) place(span time.Duration, day ReportEntries) ([]timeRange, error) {
+	busy, latest := w.busy(day)
+
+	switch w.Stacking {
+	case StackFirstGap:
+		for _, r := range freeAfter(w.Start, busy) {
+			if r.duration() >= span {
+				return []timeRange{{from: r.from, to: r.from + span}}, nil
+			}
+		}
+
+		return nil, fmt.Errorf("no gap for %v: %w", span, ErrTimeOverflow)
+	case StackFill:
+		return fill(span, freeAfter(w.Start, busy))
+	case StackLatest:
+		return fill(span, freeAfter(latest, busy))
+	}
+
+	return nil, fmt.Errorf("%s: %w", w.Stacking, ErrBadStacking)
+}
+
+// busy returns the ranges taken by the entries and the lunch, sorted, and the end of the latest entry
+// or the day start if there are none.
+func (w Workday) busy(day ReportEntries) ([]timeRange, time.Duration) {
+	busy := make([]timeRange, 0, len(day)+1)
+	latest := w.Start
+
+	for i, entry := range day {
+		r := timeRange{from: sinceMidnight(entry.StartTime), to: sinceMidnight(entry.EndTime)}
+		busy = append(busy, r)
+
+		// the latest entry counts even if it ends before the day start
+		if i == 0 || r.to > latest {
+			latest = r.to
+		}
+	}
+
+	if w.HasLunch() {
+		busy = append(busy, timeRange{from: w.LunchStart, to: w.LunchEnd})
+	}
+
+	sort.Slice(busy, func(i, j int) bool { return busy[i].from < busy[j].from })
+
+	return busy, latest
+}
+
+type timeRange struct {
+	from time.Duration
+	to   time.Duration
+}
+
+func (r timeRange) duration() time.Duration {
+	return r.to - r.from
+}
+
+// freeAfter returns free ranges of the day starting from the given time. Busy ranges must be sorted.
+func freeAfter(from time.Duration, busy []timeRange) []timeRange {
+	var (
+		free   []timeRange
+		cursor = from
+	)
+
+	for _, r := range busy {
+		if r.to <= cursor {
+			continue
+		}
+
+		if r.from > cursor {
+			free = append(free, timeRange{from: cursor, to: r.from})
+		}
+
+		cursor = r.to
+	}
+
+	if cursor < 24*time.Hour {
+		free = append(free, timeRange{from: cursor, to: 24 * time.Hour})
+	}
+
+	return free
+}
+
+// until cuts the sorted ranges at the given time.
+func until(ranges []timeRange, end time.Duration) []timeRange {
+	var out []timeRange
+
+	for _, r := range ranges {
+		if r.from >= end {
+			break
+		}
+
+		if r.to > end {
+			r.to = end
+		}
+
+		out = append(out, r)
+	}
+
+	return out
+}
+
+// fill spreads the span over the free ranges, earliest first.
+func fill(span time.Duration, free []timeRange) ([]timeRange, error) {
+	var out []timeRange
+
+	for _, r := range free {
+		if span <= 0 {
+			break
+		}
+
+		take := r.duration()
+		if take > span {
+			take = span
+		}
+
+		out = append(out, timeRange{from: r.from, to: r.from + take})
+		span -= take
+	}
+
+	if span > 0 {
+		return nil, fmt.Errorf("%v left: %w", span, ErrTimeOverflow)
+	}
+
+	return out, nil
+}
+
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("parse: %w", err)
+	}
+
+	d := sinceMidnight(t)
+	if d != d.Round(10*time.Minute) {
+		return 0, fmt.Errorf("%s: %w", value, ErrTimeNotRounded)
+	}
+
+	return d, nil
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// clockAt returns the time of the day the same way times are parsed from the history.
+func clockAt(d time.Duration) time.Time {
+	return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(d)
+}
@@ -0,0 +1,153 @@
+package types_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewWorkday(t *testing.T) {
+	Convey("NewWorkday", t, func() {
+		Convey("defaults", func() {
+			day, err := types.NewWorkday("", "", "")
+			So(err, ShouldBeNil)
+			So(day, ShouldResemble, types.DefaultWorkday())
+		})
+
+		Convey("parses", func() {
+			day, err := types.NewWorkday("08:30", "12:00 - 13:00", types.StackFirstGap)
+			So(err, ShouldBeNil)
+			So(day, ShouldResemble, types.Workday{
+				Start: 8*time.Hour + 30*time.Minute, LunchStart: 12 * time.Hour, LunchEnd: 13 * time.Hour,
+				Stacking: types.StackFirstGap,
+			})
+		})
+
+		Convey("fails", func() {
+			_, err := types.NewWorkday("08:35", "", "")
+			So(errors.Is(err, types.ErrTimeNotRounded), ShouldBeTrue)
+
+			_, err = types.NewWorkday("", "12:00", "")
+			So(errors.Is(err, types.ErrBadLunch), ShouldBeTrue)
+
+			_, err = types.NewWorkday("", "13:00-12:00", "")
+			So(errors.Is(err, types.ErrStartLargerEnd), ShouldBeTrue)
+
+			_, err = types.NewWorkday("", "", "random")
+			So(errors.Is(err, types.ErrBadStacking), ShouldBeTrue)
+		})
+	})
+}
+
+func TestReportEntry_AlignTimes(t *testing.T) {
+	Convey("AlignTimes", t, func() {
+		date := types.Date{Time: time.Date(2021, time.May, 13, 0, 0, 0, 0, time.UTC)}
+		entry := types.ReportEntry{ReportDate: date, Span: 2 * time.Hour}
+		history := types.ReportEntries{
+			{ID: "1", ReportDate: date, StartTime: clock(9, 0), EndTime: clock(10, 0)},
+			{ID: "2", ReportDate: date, StartTime: clock(11, 0), EndTime: clock(11, 30)},
+			{ID: "3", ReportDate: date.AddDate(0, 0, 1), StartTime: clock(9, 0), EndTime: clock(18, 0)},
+		}
+
+		Convey("stacks after the latest entry", func() {
+			entries, err := entry.AlignTimes(history, types.DefaultWorkday())
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"11:30-13:30"})
+		})
+
+		Convey("starts from the day start", func() {
+			day, _ := types.NewWorkday("10:00", "", "")
+
+			entries, err := entry.AlignTimes(nil, day)
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"10:00-12:00"})
+		})
+
+		Convey("stacks after the latest entry before the day start", func() {
+			early := types.ReportEntries{{ID: "1", ReportDate: date, StartTime: clock(7, 0), EndTime: clock(8, 0)}}
+
+			entries, err := entry.AlignTimes(early, types.DefaultWorkday())
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"08:00-10:00"})
+		})
+
+		Convey("splits around the lunch", func() {
+			day, _ := types.NewWorkday("", "12:00-13:00", "")
+
+			entries, err := entry.AlignTimes(history, day)
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"11:30-12:00", "13:00-14:30"})
+			So(entries[0].Span+entries[1].Span, ShouldEqual, entry.Span)
+		})
+
+		Convey("fills the first gap", func() {
+			day, _ := types.NewWorkday("", "", types.StackFirstGap)
+
+			entry.Span = time.Hour
+			entries, err := entry.AlignTimes(history, day)
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"10:00-11:00"})
+
+			entry.Span = 2 * time.Hour
+			entries, err = entry.AlignTimes(history, day)
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"11:30-13:30"})
+		})
+
+		Convey("fills the gaps", func() {
+			day, _ := types.NewWorkday("", "12:00-13:00", types.StackFill)
+
+			entry.Span = 3 * time.Hour
+			entries, err := entry.AlignTimes(history, day)
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"10:00-11:00", "11:30-12:00", "13:00-14:30"})
+
+			var span time.Duration
+			for _, piece := range entries {
+				span += piece.Span
+				So(history.Overlaps(piece), ShouldBeEmpty)
+			}
+
+			So(span, ShouldEqual, entry.Span)
+		})
+
+		Convey("keeps the range", func() {
+			entry = types.ReportEntry{ReportDate: date, StartTime: clock(14, 0), EndTime: clock(15, 0)}
+
+			entries, err := entry.AlignTimes(history, types.DefaultWorkday())
+			So(err, ShouldBeNil)
+			So(ranges(entries), ShouldResemble, []string{"14:00-15:00"})
+			So(entries[0].Span, ShouldEqual, time.Hour)
+		})
+
+		Convey("fails on overlap", func() {
+			entry = types.ReportEntry{ReportDate: date, StartTime: clock(9, 30), EndTime: clock(10, 30)}
+
+			_, err := entry.AlignTimes(history, types.DefaultWorkday())
+			So(errors.Is(err, types.ErrOverlaps), ShouldBeTrue)
+		})
+
+		Convey("fails on overflow", func() {
+			entry.Span = 13 * time.Hour
+
+			_, err := entry.AlignTimes(history, types.DefaultWorkday())
+			So(errors.Is(err, types.ErrTimeOverflow), ShouldBeTrue)
+		})
+	})
+}
+
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func ranges(entries types.ReportEntries) []string {
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, entry.StartTime.Format("15:04")+"-"+entry.EndTime.Format("15:04"))
+	}
+
+	return out
+}
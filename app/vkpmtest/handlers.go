@@ -0,0 +1,336 @@
+package vkpmtest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+const dashboardID = "42"
+
+var (
+	errBadForm    = errors.New("bad form")
+	errNoProject  = errors.New("no such project")
+	errOverlapped = errors.New("time range overlaps with existing report")
+
+	activities = []string{
+		types.ActivityEstimate, types.ActivityDevelopment, types.ActivityTesting,
+		types.ActivityBugfixing, types.ActivityManagement, types.ActivityAnalysis,
+	}
+)
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: token(), Path: "/"})
+		writeHTML(w, loginPage(""))
+
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if csrf := cookie(r, "csrftoken"); len(csrf) == 0 || csrf != r.PostForm.Get("csrfmiddlewaretoken") {
+		http.Error(w, "CSRF verification failed", http.StatusForbidden)
+
+		return
+	}
+
+	username := strings.TrimSpace(r.PostForm.Get("username"))
+
+	s.mutex.Lock()
+	password, ok := s.users[username]
+	s.mutex.Unlock()
+
+	if !ok || password != r.PostForm.Get("password") {
+		writeHTML(w, loginPage("Please enter a correct username and password."))
+
+		return
+	}
+
+	cookies := s.Session(username)
+
+	http.SetCookie(w, &http.Cookie{Name: "csrftoken", Value: cookies.CSRFToken, Path: "/"})
+	http.SetCookie(w, &http.Cookie{Name: "sessionid", Value: cookies.SessionID, Path: "/", HttpOnly: true})
+	http.Redirect(w, r, r.PostForm.Get("next"), http.StatusFound)
+}
+
+func (s *Server) dashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/dashboard/" {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	writeHTML(w, dashboardPage())
+}
+
+func (s *Server) dashboardUpdate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("id") != dashboardID {
+		http.Error(w, "unknown dashboard", http.StatusBadRequest)
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, block := range []string{"birthdays_block", "user_salary_block", "users_block"} {
+		s.blocks[block] = r.PostForm.Get(block) == "on"
+	}
+}
+
+func (s *Server) dashboardBlock(w http.ResponseWriter, r *http.Request) {
+	block := strings.Trim(strings.TrimPrefix(r.URL.Path, "/dashboard/block/"), "/")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.blocks[block] {
+		return
+	}
+
+	switch block {
+	case "user_salary_block":
+		year, month, err := yearMonth(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		writeHTML(w, salaryBlock(s.salary(year, month)))
+	case "birthdays_block":
+		writeHTML(w, birthdaysBlock(s.persons))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) userProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/dashboard/user_profile/"), "/"))
+	if err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, person := range s.persons {
+		if person.ID == id {
+			writeHTML(w, userProfilePage(person))
+
+			return
+		}
+	}
+
+	http.NotFound(w, r)
+}
+
+func (s *Server) history(w http.ResponseWriter, r *http.Request) {
+	year, month, err := yearMonth(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	writeHTML(w, historyPage(s.sortedEntries(func(entry types.ReportEntry) bool {
+		return entry.ReportDate.Year() == year && entry.ReportDate.Month() == month
+	})))
+}
+
+func (s *Server) report(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if r.Method != http.MethodPost {
+		writeHTML(w, reportPage(s.projects))
+
+		return
+	}
+
+	entry, err := s.entryFromRequest(r)
+	if err != nil {
+		_, _ = fmt.Fprint(w, err.Error())
+
+		return
+	}
+
+	s.addEntry(entry)
+}
+
+func (s *Server) breaks(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	year, err := strconv.Atoi(r.PostForm.Get("year"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var (
+		vacations types.Vacations
+		holidays  types.Holidays
+	)
+
+	for _, vacation := range s.vacations {
+		if vacation.StartDate.Year() == year {
+			vacations = append(vacations, vacation)
+		}
+	}
+
+	for _, holiday := range s.holidays {
+		if holiday.Date.Year() == year {
+			holidays = append(holidays, holiday)
+		}
+	}
+
+	writeHTML(w, breaksPage(s.paidDays, vacations, holidays))
+}
+
+func (s *Server) media(w http.ResponseWriter, _ *http.Request) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.White)
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (s *Server) salary(year int, month time.Month) types.Salary {
+	for _, salary := range s.salaries {
+		if salary.Year == year && salary.Month == month {
+			return salary
+		}
+	}
+
+	salary := types.Salary{Year: year, Month: month}
+
+	for _, entry := range s.entries {
+		if entry.ReportDate.Year() == year && entry.ReportDate.Month() == month {
+			salary.HoursByCurrDay += entry.Span.Hours()
+		}
+	}
+
+	return salary
+}
+
+func (s *Server) entryFromRequest(r *http.Request) (types.ReportEntry, error) {
+	var (
+		entry types.ReportEntry
+		err   error
+	)
+
+	if err = r.ParseForm(); err != nil {
+		return entry, fmt.Errorf("parse form: %w", err)
+	}
+
+	form := r.PostForm
+
+	if entry.ReportDate, err = types.ParseDate("2006-01-02", form.Get("report_date")); err != nil {
+		return entry, fmt.Errorf("report_date: %w", errBadForm)
+	}
+
+	for _, project := range s.projects {
+		if project.ID == form.Get("project_id") {
+			entry.Project = project
+		}
+	}
+
+	if len(entry.Project.ID) == 0 {
+		return entry, fmt.Errorf("project_id %s: %w", form.Get("project_id"), errNoProject)
+	}
+
+	activity, err := strconv.Atoi(form.Get("activity"))
+	if err != nil || activity < 0 || activity >= len(activities) {
+		return entry, fmt.Errorf("activity: %w", errBadForm)
+	}
+
+	entry.Activity = activities[activity]
+
+	if entry.Name, entry.Description = form.Get("task_name"), form.Get("task_desc"); len(entry.Name) == 0 {
+		return entry, fmt.Errorf("task_name: %w", errBadForm)
+	}
+
+	if entry.Status, err = strconv.Atoi(form.Get("status")); err != nil {
+		return entry, fmt.Errorf("status: %w", errBadForm)
+	}
+
+	start := form.Get("start_report_hours") + ":" + form.Get("start_report_minutes")
+	if entry.StartTime, err = time.Parse("15:04", start); err != nil {
+		return entry, fmt.Errorf("start: %w", errBadForm)
+	}
+
+	end := form.Get("end_report_hours") + ":" + form.Get("end_report_minutes")
+	if entry.EndTime, err = time.Parse("15:04", end); err != nil {
+		return entry, fmt.Errorf("end: %w", errBadForm)
+	}
+
+	if !entry.StartTime.Before(entry.EndTime) {
+		return entry, fmt.Errorf("range: %w", errBadForm)
+	}
+
+	if overlaps := s.entries.Overlaps(entry); len(overlaps) > 0 {
+		return entry, errOverlapped
+	}
+
+	entry.PublishDate = types.Today()
+	entry.Span = entry.EndTime.Sub(entry.StartTime)
+
+	return entry, nil
+}
+
+func yearMonth(r *http.Request) (int, time.Month, error) {
+	if err := r.ParseForm(); err != nil {
+		return 0, 0, fmt.Errorf("parse form: %w", err)
+	}
+
+	year, err := strconv.Atoi(r.PostForm.Get("year"))
+	if err != nil {
+		return 0, 0, fmt.Errorf("year: %w", errBadForm)
+	}
+
+	month, err := strconv.Atoi(r.PostForm.Get("month"))
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, fmt.Errorf("month: %w", errBadForm)
+	}
+
+	return year, time.Month(month), nil
+}
+
+func writeHTML(w http.ResponseWriter, page string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprint(w, page)
+}
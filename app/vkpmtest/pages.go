@@ -0,0 +1,185 @@
+package vkpmtest
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kudrykv/go-vkpm/app/types"
+)
+
+func page(body string) string {
+	return "<!DOCTYPE html>\n<html><head><title>VKPM</title></head><body>\n" + body + "\n</body></html>\n"
+}
+
+func loginPage(message string) string {
+	var errs string
+	if len(message) > 0 {
+		errs = `<ul class="errorlist"><li>` + html.EscapeString(message) + `</li></ul>`
+	}
+
+	return page(errs + `
+<form method="post" action="/login/">
+<input type="text" name="username">
+<input type="password" name="password">
+<input type="hidden" name="next" value="/">
+<button type="submit">Sign in</button>
+</form>`)
+}
+
+func dashboardPage() string {
+	return page(`
+<form id="dashboard_settings" method="post" action="/dashboard/update/">
+<input type="hidden" name="id" value="` + dashboardID + `">
+<input type="checkbox" name="birthdays_block">
+<input type="checkbox" name="user_salary_block">
+<input type="checkbox" name="users_block">
+</form>`)
+}
+
+func salaryBlock(s types.Salary) string {
+	money := func(f float64) string { return "$" + strconv.FormatFloat(f, 'f', 2, 64) }
+	num := func(f float64) string { return strconv.FormatFloat(f, 'f', 1, 64) }
+
+	return `<table>
+<tr><td>hourly rate: ` + money(s.RatePerHour) + `</td><td>rate: ` + money(s.Rate) + `</td></tr>
+<tr><td>Working days in month</td><td>` + num(s.WorkingDaysInMonth) + `</td></tr>
+<tr><td>Hours by the Current Day</td><td>` + num(s.HoursByCurrDay) + `</td><td>` + money(s.DollarsByCurrDay) + `</td></tr>
+<tr><td>Expected Compensation</td><td></td><td>` + money(s.ExpectedSalary) + `</td></tr>
+<tr><td>Break days</td><td>` + num(s.VacationHours) + `</td><td>` + money(s.VacationDollars) + `</td></tr>
+<tr><td>Overtime</td><td>` + num(s.OvertimeHours) + `</td><td>` + money(s.OvertimeDollars) + `</td></tr>
+<tr><td>Bonus</td><td></td><td>` + money(s.BonusDollars) + `</td></tr>
+<tr><td>Total / Paid</td><td>` + money(s.Total) + ` / ` + money(s.Paid) + `</td></tr>
+</table>`
+}
+
+func birthdaysBlock(persons types.Persons) string {
+	rows := make([]string, 0, len(persons))
+
+	for _, p := range persons {
+		rows = append(rows, fmt.Sprintf(
+			`<tr><td><a href="/dashboard/user_profile/%d">%s</a></td><td>%s</td><td>%s</td></tr>`,
+			p.ID, html.EscapeString(p.Name), p.Birthday.Format("02 January"), html.EscapeString(p.Team),
+		))
+	}
+
+	return `<div id="dashboard_birthdays_block"><table><thead><tr><th>Name</th><th>Birthday</th><th>Team</th></tr></thead>
+<tbody>` + strings.Join(rows, "\n") + `</tbody></table></div>`
+}
+
+func userProfilePage(p types.Person) string {
+	row := func(key, value string) string {
+		return "<tr><td>" + key + "</td><td>" + html.EscapeString(value) + "</td></tr>"
+	}
+
+	photo := p.PhotoURL
+	if len(photo) == 0 {
+		photo = "/media/photos/" + strconv.Itoa(p.ID) + ".png"
+	}
+
+	return page(`<h1>` + html.EscapeString(p.Name) + `</h1>
+<div class="status">` + html.EscapeString(p.Status) + `</div>
+<table>
+` + row("Email", p.Email) + `
+` + row("Skype", p.Skype) + `
+` + row("Team", p.Team) + `
+` + row("Grade", p.Grade) + `
+` + row("English Level", p.EnglishLevel) + `
+` + row("English Details", p.EnglishDetails) + `
+<tr><td>Photo</td><td><img src="` + html.EscapeString(photo) + `"></td></tr>
+</table>`)
+}
+
+func historyPage(entries types.ReportEntries) string {
+	rows := make([]string, 0, len(entries))
+
+	for _, e := range entries {
+		options := make([]string, 0, len(activities))
+
+		for i, activity := range activities {
+			selected := ""
+			if activity == e.Activity {
+				selected = " selected"
+			}
+
+			options = append(options, fmt.Sprintf(`<option value="%d"%s>%s</option>`, i, selected, activity))
+		}
+
+		span := e.EndTime.Sub(e.StartTime)
+
+		rows = append(rows, "<tr>"+
+			"<td>"+e.ID+"</td>"+
+			"<td>"+e.PublishDate.Format("2 Jan, Mon 15:04")+"</td>"+
+			"<td>"+e.ReportDate.Format("2 Jan, 2006")+"</td>"+
+			"<td>"+html.EscapeString(e.Project.Name)+"</td>"+
+			"<td><select>"+strings.Join(options, "")+"</select></td>"+
+			"<td>"+html.EscapeString(e.Name)+"</td>"+
+			"<td>"+html.EscapeString(e.Description)+"</td>"+
+			"<td>"+strconv.Itoa(e.Status)+"%</td>"+
+			"<td>"+e.StartTime.Format("15:04")+"</td>"+
+			"<td>"+e.EndTime.Format("15:04")+"</td>"+
+			fmt.Sprintf("<td>%d:%02dh</td>", int(span.Hours()), int(span.Minutes())%60)+
+			"</tr>")
+	}
+
+	return page(`<table id="history"><thead><tr><th>ID</th></tr></thead>
+<tbody>` + strings.Join(rows, "\n") + `</tbody></table>`)
+}
+
+func reportPage(projects types.Projects) string {
+	options := make([]string, 0, len(projects))
+
+	for _, p := range projects {
+		options = append(options, `<option value="`+html.EscapeString(p.ID)+`">`+html.EscapeString(p.Name)+`</option>`)
+	}
+
+	return page(`<form method="post" action="/report/"><select id="id_project" name="project_id">` +
+		strings.Join(options, "") + `</select></form>`)
+}
+
+func breaksPage(paidDays int, vacations types.Vacations, holidays types.Holidays) string {
+	vacs := make([]string, 0, len(vacations))
+
+	for _, v := range vacations {
+		end := "-"
+		if !v.EndDate.IsZero() {
+			end = v.EndDate.Format("2 January 2006")
+		}
+
+		paid := "Unpaid"
+		if v.Paid {
+			paid = "Paid"
+		}
+
+		vacs = append(vacs, "<tr>"+
+			"<td>"+html.EscapeString(v.ID)+"</td>"+
+			"<td>"+html.EscapeString(v.Type)+"</td>"+
+			"<td>"+v.StartDate.Format("2 January 2006")+"</td>"+
+			"<td>"+end+"</td>"+
+			"<td>"+strconv.FormatFloat(v.Span.Hours()/24, 'f', 1, 64)+"</td>"+
+			"<td>"+html.EscapeString(v.Status)+"</td>"+
+			"<td>"+html.EscapeString(v.Note)+"</td>"+
+			"<td>"+paid+"</td>"+
+			"</tr>")
+	}
+
+	hols := make([]string, 0, len(holidays))
+	month := time.Month(0)
+
+	for _, h := range holidays {
+		if h.Date.Month() != month {
+			month = h.Date.Month()
+			hols = append(hols, "<tr><td>"+month.String()+"</td><td></td><td></td></tr>")
+		}
+
+		hols = append(hols, "<tr><td></td><td>"+h.Date.Format("02 January 2006")+"</td>"+
+			"<td>"+html.EscapeString(h.Name)+"</td></tr>")
+	}
+
+	return page(`<div class="vac_status_message">You have ` + strconv.Itoa(paidDays) + ` paid vacation days left</div>
+<table id="vacations"><thead><tr><th>ID</th></tr></thead>
+<tbody>` + strings.Join(vacs, "\n") + `</tbody></table>
+<div class="holidays_list"><table><tbody>` + strings.Join(hols, "\n") + `</tbody></table></div>`)
+}
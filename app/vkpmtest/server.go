@@ -0,0 +1,303 @@
+// Package vkpmtest provides a stand-in VKPM server for end-to-end tests.
+//
+// The server speaks just enough of the VKPM HTML to be understood by services.API:
+// it authenticates users, keeps report entries, projects, salaries, vacations, holidays
+// and persons in memory, and renders them the way the real site does.
+package vkpmtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kudrykv/go-vkpm/app/config"
+	"github.com/kudrykv/go-vkpm/app/types"
+	"github.com/kudrykv/littlehttp"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mutex     sync.Mutex
+	users     map[string]string
+	sessions  map[string]string
+	blocks    map[string]bool
+	requests  []string
+	nextID    int
+	paidDays  int
+	projects  types.Projects
+	entries   types.ReportEntries
+	salaries  types.Salaries
+	vacations types.Vacations
+	holidays  types.Holidays
+	persons   types.Persons
+}
+
+// NewServer starts a server with no users and no data. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		users:    map[string]string{},
+		sessions: map[string]string{},
+		blocks:   map[string]bool{},
+		nextID:   1000,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/", s.login)
+	mux.HandleFunc("/dashboard/", s.authed(s.dashboard))
+	mux.HandleFunc("/dashboard/update/", s.authed(s.dashboardUpdate))
+	mux.HandleFunc("/dashboard/block/", s.authed(s.dashboardBlock))
+	mux.HandleFunc("/dashboard/user_profile/", s.authed(s.userProfile))
+	mux.HandleFunc("/history/", s.authed(s.history))
+	mux.HandleFunc("/report/", s.authed(s.report))
+	mux.HandleFunc("/breaks/", s.authed(s.breaks))
+	mux.HandleFunc("/media/", s.authed(s.media))
+
+	s.Server = httptest.NewServer(s.log(mux))
+
+	return s
+}
+
+func (s *Server) WithUser(username, password string) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.users[username] = password
+
+	return s
+}
+
+func (s *Server) WithProjects(projects ...types.Project) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.projects = append(s.projects, projects...)
+
+	return s
+}
+
+// WithEntries stores entries as if they were reported before. Entries without ID get one assigned.
+func (s *Server) WithEntries(entries ...types.ReportEntry) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, entry := range entries {
+		s.addEntry(entry)
+	}
+
+	return s
+}
+
+func (s *Server) WithSalaries(salaries ...types.Salary) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.salaries = append(s.salaries, salaries...)
+
+	return s
+}
+
+func (s *Server) WithVacations(paidDays int, vacations ...types.Vacation) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paidDays = paidDays
+	s.vacations = append(s.vacations, vacations...)
+
+	return s
+}
+
+func (s *Server) WithHolidays(holidays ...types.Holiday) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.holidays = append(s.holidays, holidays...)
+
+	return s
+}
+
+func (s *Server) WithPersons(persons ...types.Person) *Server {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.persons = append(s.persons, persons...)
+
+	return s
+}
+
+// Session signs the user in bypassing the login form and returns the cookies to use.
+func (s *Server) Session(username string) config.Cookies {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cookies := config.Cookies{CSRFToken: token(), SessionID: token()}
+	s.sessions[cookies.SessionID] = username
+
+	return cookies
+}
+
+// ExpireSessions drops every active session, as the real site does after a while.
+func (s *Server) ExpireSessions() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sessions = map[string]string{}
+}
+
+// Entries returns a copy of the reported entries ordered by report date and start time.
+func (s *Server) Entries() types.ReportEntries {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sortedEntries(func(types.ReportEntry) bool { return true })
+}
+
+// Requests returns "METHOD /path" of every request served so far.
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cp := make([]string, len(s.requests))
+	copy(cp, s.requests)
+
+	return cp
+}
+
+// Count returns how many times the given "METHOD /path" was requested.
+func (s *Server) Count(request string) int {
+	var count int
+
+	for _, r := range s.Requests() {
+		if r == request {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Domain returns the host the server listens on, to be used as config.Config Domain.
+func (s *Server) Domain() string {
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+// Config returns a config pointing to the server.
+func (s *Server) Config(cookies config.Cookies) config.Config {
+	return config.Config{Domain: s.Domain(), Cookies: cookies}
+}
+
+// LittleHTTP returns a client configured the same way cmd/vkpm configures it, but aimed at the server.
+func (s *Server) LittleHTTP() *littlehttp.LittleHTTP {
+	client, err := littlehttp.New(littlehttp.Parameters{
+		Client: &http.Client{
+			Transport: http.DefaultTransport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		URLPrefix:  s.URL,
+		Marshaller: marshaller,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+func (s *Server) log(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+		s.mutex.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) authed(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mutex.Lock()
+		_, ok := s.sessions[cookie(r, "sessionid")]
+		s.mutex.Unlock()
+
+		if !ok {
+			http.Redirect(w, r, "/login/?next="+url.QueryEscape(r.URL.Path), http.StatusFound)
+
+			return
+		}
+
+		if r.Method == http.MethodPost && r.Header.Get("x-csrftoken") != cookie(r, "csrftoken") {
+			http.Error(w, "CSRF verification failed", http.StatusForbidden)
+
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) addEntry(entry types.ReportEntry) types.ReportEntry {
+	if len(entry.ID) == 0 {
+		entry.ID = strconv.Itoa(s.nextID)
+		s.nextID++
+	}
+
+	if entry.Span == 0 {
+		entry.Span = entry.EndTime.Sub(entry.StartTime)
+	}
+
+	s.entries = append(s.entries, entry)
+
+	return entry
+}
+
+func (s *Server) sortedEntries(filter func(types.ReportEntry) bool) types.ReportEntries {
+	out := make(types.ReportEntries, 0, len(s.entries))
+
+	for _, entry := range s.entries {
+		if filter(entry) {
+			out = append(out, entry)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].ReportDate.Equal(out[j].ReportDate) {
+			return out[i].ReportDate.Before(out[j].ReportDate.Time)
+		}
+
+		return out[i].StartTime.Before(out[j].StartTime)
+	})
+
+	return out
+}
+
+func cookie(r *http.Request, name string) string {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+
+	return c.Value
+}
+
+func token() string {
+	bts := make([]byte, 16)
+	if _, err := rand.Read(bts); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(bts)
+}
+
+func marshaller(src any) (string, []byte, error) {
+	values, _ := src.(url.Values)
+
+	return "application/x-www-form-urlencoded", []byte(values.Encode()), nil
+}
@@ -43,7 +43,8 @@ func main() {
 	}
 
 	var (
-		p          = printer.Printer{W: os.Stdout, E: os.Stderr}
+		output     = printer.OutputText
+		p          = printer.Printer{W: os.Stdout, E: os.Stderr, Output: &output}
 		httpClient = &http.Client{
 			Transport: http.DefaultTransport,
 			CheckRedirect: func(*http.Request, []*http.Request) error {
@@ -64,31 +65,60 @@ func main() {
 	}
 
 	api := services.NewAPI(client, cfg).WithCookies(cfg.Cookies)
+	relogin := false
 
 	app := &cli.App{
 		Name:    "vkpm",
 		Usage:   "cli tool to avoid clicking through VKPM UI",
 		Version: "0.0.6",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "output", Aliases: []string{"o"}, Value: printer.OutputText, Destination: &output,
+				Usage: "print data as text, json, yaml or csv",
+			},
+			&cli.BoolFlag{
+				Name: "relogin", Destination: &relogin,
+				Usage: "sign in again when the session expires, asking for the password if there is no password source",
+			},
+		},
+		Before: func(*cli.Context) error {
+			if commands.CanRelogin(cfg, relogin) {
+				api.WithRelogin(commands.Credentials(p, cfg), func(cookies config.Cookies) error {
+					cfg.Cookies = cookies
+
+					return cfg.Write()
+				})
+			}
+
+			return printer.TestOutput(output)
+		},
 		Commands: []*cli.Command{
 			commands.Config(cfg),
 			commands.Login(p, cfg, api),
 			commands.Dashboard(p, cfg, api),
+			commands.Today(p, cfg, api),
 			commands.Report(p, cfg, api),
+			commands.Timer(p, cfg, api),
+			commands.Note(p, cfg),
+			commands.Import(p, cfg, api),
+			commands.Export(p, cfg, api),
 			commands.History(p, cfg, api),
+			commands.Week(p, cfg, api),
+			commands.Calendar(p, cfg, api),
 			commands.Stat(p, cfg, api),
 			commands.Vacations(p, cfg, api),
 			{
 				Name:  "users",
 				Usage: "search and get detailed info about users",
 				Subcommands: cli.Commands{
-					commands.UsersSearch(cfg, api),
-					commands.UsersInfo(cfg, api),
+					commands.UsersSearch(p, cfg, api),
+					commands.UsersInfo(p, cfg, api),
 				},
 			},
 			{
 				Name: "projects",
 				Subcommands: cli.Commands{
-					commands.ProjectsList(cfg, api),
+					commands.ProjectsList(p, cfg, api),
 				},
 			},
 		},